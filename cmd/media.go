@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maksymshtarkberg/music-player-go/internal/database"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// coverSizes maps the ?size= values of the cover endpoint onto
+// storage.ThumbnailSizes.
+var coverSizes = map[string]int{
+	"small":  64,
+	"medium": 256,
+	"large":  640,
+}
+
+func StreamSongHTTP(c *gin.Context) {
+	serveSongFile(c, func(song models.Song) string { return song.SongFileID }, nil)
+}
+
+// StreamCoverHTTP serves the original cover, or a thumbnail when
+// ?size=small|medium|large is given and one has been generated.
+func StreamCoverHTTP(c *gin.Context) {
+	size := c.Query("size")
+	pixels, ok := coverSizes[size]
+	if size != "" && size != "original" && !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "size must be one of original, small, medium, large"})
+		return
+	}
+
+	var variant func(*gridfs.Bucket, primitive.ObjectID) primitive.ObjectID
+	if ok {
+		variant = func(bucket *gridfs.Bucket, coverID primitive.ObjectID) primitive.ObjectID {
+			thumbnailID, err := storage.FindThumbnail(c.Request.Context(), bucket, coverID, pixels)
+			if err != nil {
+				return coverID
+			}
+			return thumbnailID
+		}
+	}
+
+	serveSongFile(c, func(song models.Song) string { return song.AlbumCoverID }, variant)
+}
+
+// serveSongFile streams a GridFS file with Range, ETag and Last-Modified support.
+// GridFS files are immutable, so the file ID doubles as a strong ETag. variant,
+// if set, may substitute another file derived from the picked one.
+func serveSongFile(c *gin.Context, pick func(models.Song) string, variant func(*gridfs.Bucket, primitive.ObjectID) primitive.ObjectID) {
+	songID, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid song ID"})
+		return
+	}
+
+	// Files of trashed songs are not served until the song is restored.
+	var song models.Song
+	err = database.GetCollection("songs").FindOne(c.Request.Context(), bson.M{"_id": songID, "deletedAt": bson.M{"$exists": false}}).Decode(&song)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Song not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileID, err := primitive.ObjectIDFromHex(pick(song))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	bucket, err := gridfs.NewBucket(database.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if variant != nil {
+		fileID = variant(bucket, fileID)
+	}
+
+	file, err := storage.NewReadSeeker(bucket, fileID)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	contentType, err := storage.DetectContentType(bucket, file.File())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("ETag", `"`+fileID.Hex()+`"`)
+	c.Header("Cache-Control", "private, max-age=86400")
+
+	http.ServeContent(c.Writer, c.Request, "", file.File().UploadDate, file)
+}
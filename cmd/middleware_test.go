@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestRedactAccessToken(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/api/v1/songs/1/stream", "/api/v1/songs/1/stream"},
+		{"/api/v1/songs/1/cover?size=small", "/api/v1/songs/1/cover?size=small"},
+		{"/api/v1/songs/1/stream?access_token=eyJ.abc.def", "/api/v1/songs/1/stream?access_token=REDACTED"},
+		{"/api/v1/songs/1/cover?size=small&access_token=eyJ.abc.def", "/api/v1/songs/1/cover?access_token=REDACTED&size=small"},
+		{"/api/v1/songs/1/stream?access_token=a&access_token=b", "/api/v1/songs/1/stream?access_token=REDACTED"},
+		{"/api/v1/songs/1/stream?access_token=%zz", "/api/v1/songs/1/stream?[REDACTED]"},
+	}
+
+	for _, c := range cases {
+		if got := redactAccessToken(c.path); got != c.want {
+			t.Errorf("redactAccessToken(%q) = %q, want %q", c.path, got, c.want)
+		}
+	}
+}
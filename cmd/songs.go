@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+)
+
+const (
+	songRequestTimeout = 10 * time.Second
+	uploadChunkSize    = 1024 * 1024
+)
+
+var (
+	songClient pb.SongServiceClient
+
+	jsonMarshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+)
+
+// updateSongBody is a partial update: only the fields present in the JSON are
+// changed. Version, when set, is the version the client last read.
+type updateSongBody struct {
+	Title       *string `json:"title"`
+	Artist      *string `json:"artist"`
+	Album       *string `json:"album"`
+	Description *string `json:"description"`
+	Version     int64   `json:"version"`
+}
+
+// request builds the update request, with a mask naming the fields present.
+func (b updateSongBody) request(songID string) (*pb.UpdateSongMetadataRequest, error) {
+	req := &pb.UpdateSongMetadataRequest{
+		SongId:     songID,
+		UpdateMask: &fieldmaskpb.FieldMask{},
+		Version:    b.Version,
+	}
+	for _, field := range []struct {
+		path  string
+		value *string
+		dest  *string
+	}{
+		{"title", b.Title, &req.Title},
+		{"artist", b.Artist, &req.Artist},
+		{"album", b.Album, &req.Album},
+		{"description", b.Description, &req.Description},
+	} {
+		if field.value != nil {
+			*field.dest = *field.value
+			req.UpdateMask.Paths = append(req.UpdateMask.Paths, field.path)
+		}
+	}
+
+	if len(req.UpdateMask.Paths) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no fields to update")
+	}
+	return req, nil
+}
+
+func ListSongsREST(c *gin.Context) {
+	options, err := songListOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	requestData, err := proto.Marshal(&pb.GetAllSongsRequest{Options: options})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.all", requestData, c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.GetAllSongsResponse{})
+}
+
+func ListUserSongsREST(c *gin.Context) {
+	options, err := songListOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	requestData, err := proto.Marshal(&pb.GetUserSongsRequest{UserId: c.Param("id"), Options: options})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.user", requestData, c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.GetUserSongsResponse{})
+}
+
+// songListOptions reads ?page_size=&page_token=&sort=title|artist|uploadedAt&order=desc
+// &artist=&album=&uploaded_by= into SongListOptions.
+func songListOptions(c *gin.Context) (*pb.SongListOptions, error) {
+	options := &pb.SongListOptions{
+		PageToken:  c.Query("page_token"),
+		Descending: c.Query("order") == "desc",
+		Artist:     c.Query("artist"),
+		Album:      c.Query("album"),
+		UploadedBy: c.Query("uploaded_by"),
+	}
+
+	if pageSize := c.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			return nil, status.Error(codes.InvalidArgument, "page_size must be a positive integer")
+		}
+		options.PageSize = int32(size)
+	}
+
+	switch c.Query("sort") {
+	case "":
+	case "title":
+		options.SortBy = pb.SongSortField_SONG_SORT_FIELD_TITLE
+	case "artist":
+		options.SortBy = pb.SongSortField_SONG_SORT_FIELD_ARTIST
+	case "uploadedAt":
+		options.SortBy = pb.SongSortField_SONG_SORT_FIELD_UPLOADED_AT
+	default:
+		return nil, status.Error(codes.InvalidArgument, "sort must be one of title, artist, uploadedAt")
+	}
+
+	return options, nil
+}
+
+func SearchSongsREST(c *gin.Context) {
+	req := &pb.SearchSongsRequest{
+		Query:     c.Query("q"),
+		PageToken: c.Query("page_token"),
+	}
+	if pageSize := c.Query("page_size"); pageSize != "" {
+		size, err := strconv.ParseInt(pageSize, 10, 32)
+		if err != nil || size < 0 {
+			respondError(c, status.Error(codes.InvalidArgument, "page_size must be a positive integer"))
+			return
+		}
+		req.PageSize = int32(size)
+	}
+
+	requestData, err := proto.Marshal(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.search", requestData, c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.SearchSongsResponse{})
+}
+
+func GetSongREST(c *gin.Context) {
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.get", []byte(c.Param("id")), c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.SongMetadata{})
+}
+
+func UpdateSongREST(c *gin.Context) {
+	var body updateSongBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		respondError(c, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	req, err := body.request(c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	requestData, err := proto.Marshal(req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.update", requestData, c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.UpdateSongMetadataResponse{})
+}
+
+// DeleteSongREST moves a song to the trash.
+func DeleteSongREST(c *gin.Context) {
+	ctx, cancel := songServiceContext(c)
+	defer cancel()
+
+	response, err := songClient.DeleteSong(ctx, &pb.DeleteSongRequest{SongId: c.Param("id")})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+// ListTrashREST lists the caller's trashed songs and takes the same query
+// parameters as ListSongsREST.
+func ListTrashREST(c *gin.Context) {
+	options, err := songListOptions(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	requestData, err := proto.Marshal(&pb.ListTrashRequest{Options: options})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.trash", requestData, c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.ListTrashResponse{})
+}
+
+func RestoreSongREST(c *gin.Context) {
+	msg, err := natsrpc.Request(c.Request.Context(), nc, "songs.restore", []byte(c.Param("id")), c.GetString(accessTokenKey), songRequestTimeout)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondProto(c, http.StatusOK, msg.Data, &pb.RestoreSongResponse{})
+}
+
+// PurgeSongREST goes through the gRPC server because purging a song also removes its GridFS files.
+func PurgeSongREST(c *gin.Context) {
+	ctx, cancel := songServiceContext(c)
+	defer cancel()
+
+	response, err := songClient.PurgeSong(ctx, &pb.PurgeSongRequest{SongId: c.Param("id")})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusOK, response)
+}
+
+// UploadSongREST accepts a multipart form with title, artist, album and description
+// fields, a required song_file and an optional album_cover, and streams them to the
+// gRPC server so uploads are not bound by the gRPC message size limit.
+func UploadSongREST(c *gin.Context) {
+	songHeader, err := c.FormFile("song_file")
+	if err != nil {
+		respondError(c, status.Error(codes.InvalidArgument, "song_file is required"))
+		return
+	}
+
+	songFile, err := songHeader.Open()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer songFile.Close()
+
+	checksum := sha256.New()
+	if _, err := io.Copy(checksum, songFile); err != nil {
+		respondError(c, err)
+		return
+	}
+	if _, err := songFile.Seek(0, io.SeekStart); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	ctx, cancel := songServiceContext(c)
+	defer cancel()
+
+	stream, err := songClient.UploadSongStream(ctx)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err = stream.Send(&pb.UploadSongStreamRequest{
+		Payload: &pb.UploadSongStreamRequest_Metadata{Metadata: &pb.UploadSongStreamMetadata{
+			Title:       c.PostForm("title"),
+			Artist:      c.PostForm("artist"),
+			Album:       c.PostForm("album"),
+			Description: c.PostForm("description"),
+			SongSize:    songHeader.Size,
+			SongSha256:  hex.EncodeToString(checksum.Sum(nil)),
+		}},
+	})
+	if err != nil {
+		respondError(c, uploadError(stream, err))
+		return
+	}
+
+	err = sendChunks(songFile, func(chunk []byte) error {
+		return stream.Send(&pb.UploadSongStreamRequest{
+			Payload: &pb.UploadSongStreamRequest_SongChunk{SongChunk: chunk},
+		})
+	})
+	if err != nil {
+		respondError(c, uploadError(stream, err))
+		return
+	}
+
+	if coverHeader, err := c.FormFile("album_cover"); err == nil {
+		if err := sendCover(stream, coverHeader); err != nil {
+			respondError(c, uploadError(stream, err))
+			return
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	writeProto(c, http.StatusCreated, response)
+}
+
+func sendCover(stream pb.SongService_UploadSongStreamClient, header *multipart.FileHeader) error {
+	cover, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer cover.Close()
+
+	return sendChunks(cover, func(chunk []byte) error {
+		return stream.Send(&pb.UploadSongStreamRequest{
+			Payload: &pb.UploadSongStreamRequest_AlbumCoverChunk{AlbumCoverChunk: chunk},
+		})
+	})
+}
+
+func sendChunks(r io.Reader, send func([]byte) error) error {
+	buffer := make([]byte, uploadChunkSize)
+	for {
+		n, err := r.Read(buffer)
+		if n > 0 {
+			if err := send(buffer[:n]); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// uploadError surfaces the server's status when Send fails because the server already
+// rejected the upload and closed the stream.
+func uploadError(stream pb.SongService_UploadSongStreamClient, err error) error {
+	if errors.Is(err, io.EOF) {
+		if _, recvErr := stream.CloseAndRecv(); recvErr != nil {
+			return recvErr
+		}
+	}
+	return err
+}
+
+func songServiceContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx := metadata.AppendToOutgoingContext(c.Request.Context(), "authorization", "Bearer "+c.GetString(accessTokenKey))
+	return context.WithTimeout(ctx, 10*time.Minute)
+}
+
+func respondProto(c *gin.Context, code int, data []byte, message proto.Message) {
+	if err := proto.Unmarshal(data, message); err != nil {
+		respondError(c, status.Errorf(codes.Internal, "failed to decode response: %v", err))
+		return
+	}
+	writeProto(c, code, message)
+}
+
+func writeProto(c *gin.Context, code int, message proto.Message) {
+	body, err := jsonMarshaler.Marshal(message)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", body)
+}
+
+// respondError writes the error envelope shared by all song endpoints:
+// {"error": "<message>", "code": "<gRPC status code>"}.
+func respondError(c *gin.Context, err error) {
+	if errors.Is(err, nats.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		err = status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	if errors.Is(err, nats.ErrNoResponders) {
+		err = status.Error(codes.Unavailable, err.Error())
+	}
+
+	st := status.Convert(err)
+	c.AbortWithStatusJSON(httpStatus(st.Code()), gin.H{
+		"error": st.Message(),
+		"code":  st.Code().String(),
+	})
+}
+
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.DataLoss:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,27 +2,32 @@ package main
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+	"github.com/maksymshtarkberg/music-player-go/pkg/password"
+	"github.com/maksymshtarkberg/music-player-go/pkg/token"
 	"github.com/nats-io/nats.go"
 )
 
 var (
 	nc          *nats.Conn
 	redisClient *redis.Client
+	jwtSecret   []byte
 	ctx         = context.Background()
 )
 
 func main() {
 	var err error
 
+	jwtSecret, err = token.SecretFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	redisClient = redis.NewClient(&redis.Options{
 		Addr: "localhost:6379",
 	})
@@ -35,6 +40,9 @@ func main() {
 
 	nc.Subscribe("auth.register", handleRegister)
 	nc.Subscribe("auth.authenticate", handleAuthenticate)
+	nc.Subscribe("auth.refresh", handleRefresh)
+	nc.Subscribe("auth.logout", handleLogout)
+	nc.Subscribe("auth.logout_all", handleLogoutAll)
 
 	log.Println("Server auth is running...")
 
@@ -49,17 +57,6 @@ func handleRegister(m *nats.Msg) {
 		return
 	}
 
-	existingToken, err := redisClient.Get(ctx, user.Username).Result()
-	if err != nil && err != redis.Nil {
-		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
-		return
-	}
-
-	if existingToken != "" {
-		nc.Publish(m.Reply, []byte(`{"error": "User already registered"}`))
-		return
-	}
-
 	userExist, err := nc.Request("users.get", []byte(user.Username), 5*nats.DefaultTimeout)
 	if err != nil {
 		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Users get service error: %v"}`, err)))
@@ -74,24 +71,30 @@ func handleRegister(m *nats.Msg) {
 		return
 	}
 
-	token := fmt.Sprintf("%d", time.Now().UnixNano())
-	err = redisClient.Set(ctx, user.Username, token, time.Hour*24*7).Err()
+	response, err := nc.Request("users.register", m.Data, nats.DefaultTimeout)
 	if err != nil {
-		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Users reg service error: %v"}`, err)))
 		return
 	}
 
-	response, err := nc.Request("users.register", m.Data, nats.DefaultTimeout)
-	if err != nil {
-		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Users reg service error: %v"}`, err)))
+	var registered struct {
+		Error    string   `json:"error"`
+		UserID   string   `json:"userID"`
+		UserName string   `json:"userName"`
+		Roles    []string `json:"roles"`
+	}
+	if err := json.Unmarshal(response.Data, &registered); err != nil || registered.Error != "" {
+		nc.Publish(m.Reply, response.Data)
 		return
 	}
 
-	finalResponse := map[string]interface{}{
-		"message": "User registered successfully",
-		"token":   token,
-		"data":    json.RawMessage(response.Data),
+	finalResponse, err := issueSession(registered.UserID, registered.UserName, registered.Roles, "")
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to issue token: %v"}`, err)))
+		return
 	}
+	finalResponse["message"] = "User registered successfully"
+	finalResponse["data"] = json.RawMessage(response.Data)
 
 	responseData, err := json.Marshal(finalResponse)
 	if err != nil {
@@ -130,40 +133,83 @@ func handleAuthenticate(m *nats.Msg) {
 		nc.Publish(m.Reply, []byte(`{"error": "Invalid username or password"}`))
 		return
 	}
-	hashedPassword := HashPassword(credentials.Password)
 
-	if user.Password != hashedPassword {
+	ok, needsRehash, err := password.Verify(credentials.Password, user.Password)
+	if err != nil || !ok {
 		nc.Publish(m.Reply, []byte(`{"error": "Invalid username or password"}`))
 		return
 	}
 
-	token, err := redisClient.Get(ctx, credentials.Username).Result()
-	if err != nil && err != redis.Nil {
+	if needsRehash {
+		rehashPassword(user, credentials.Password)
+	}
+
+	session, err := issueSession(user.ID.Hex(), user.Username, user.Roles, "")
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to issue token: %v"}`, err)))
+		return
+	}
+	session["status"] = "Authenticated"
+	session["userId"] = user.ID.Hex()
+	session["userName"] = user.Username
+	session["roles"] = user.Roles
+
+	responseData, err := json.Marshal(session)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to serialize response: %v"}`, err)))
+		return
+	}
+
+	nc.Publish(m.Reply, responseData)
+}
+
+func handleRefresh(m *nats.Msg) {
+	var request struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+	err := json.Unmarshal(m.Data, &request)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Invalid data: %v"}`, err)))
+		return
+	}
+
+	claims, err := token.VerifyRefresh(jwtSecret, request.RefreshToken)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(`{"error": "Invalid refresh token"}`))
+		return
+	}
+
+	err = consumeRefresh(claims)
+	if err == errRefreshReused || err == errRefreshRevoked {
+		log.Printf("Rejected refresh for user %s (family %s): %v", claims.UserID, claims.Family, err)
+		nc.Publish(m.Reply, []byte(`{"error": "Refresh token revoked"}`))
+		return
+	}
+	if err != nil {
 		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
 		return
 	}
 
-	if token == "" {
-		token = fmt.Sprintf("%d", time.Now().UnixNano())
-		err = redisClient.Set(ctx, user.Username, token, time.Hour*24*7).Err()
-		if err != nil {
-			nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
-			return
-		}
+	response, err := nc.Request("users.get", []byte(claims.Username), nats.DefaultTimeout)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Users service error: %v"}`, err)))
+		return
+	}
 
-		token, err = redisClient.Get(ctx, credentials.Username).Result()
-		if err != nil {
-			nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to retrieve token: %v"}`, err)))
-			return
-		}
+	var user models.User
+	if string(response.Data) == "false" || json.Unmarshal(response.Data, &user) != nil || user.ID.Hex() != claims.UserID {
+		nc.Publish(m.Reply, []byte(`{"error": "User not registered"}`))
+		return
 	}
 
-	responseData, _ := json.Marshal(map[string]string{
-		"status":   "Authenticated",
-		"token":    token,
-		"userId":   user.ID.Hex(),
-		"userName": user.Username,
-	})
+	session, err := issueSession(claims.UserID, user.Username, user.Roles, claims.Family)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to issue token: %v"}`, err)))
+		return
+	}
+	session["status"] = "Refreshed"
+
+	responseData, err := json.Marshal(session)
 	if err != nil {
 		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to serialize response: %v"}`, err)))
 		return
@@ -172,8 +218,79 @@ func handleAuthenticate(m *nats.Msg) {
 	nc.Publish(m.Reply, responseData)
 }
 
-func HashPassword(password string) string {
-	hash := sha256.New()
-	hash.Write([]byte(password))
-	return hex.EncodeToString(hash.Sum(nil))
+func handleLogout(m *nats.Msg) {
+	var request struct {
+		RefreshToken string `json:"refreshToken"`
+	}
+	err := json.Unmarshal(m.Data, &request)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Invalid data: %v"}`, err)))
+		return
+	}
+
+	claims, err := token.VerifyRefresh(jwtSecret, request.RefreshToken)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(`{"error": "Invalid refresh token"}`))
+		return
+	}
+
+	if err := revokeFamily(claims.Family); err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
+		return
+	}
+	redisClient.Del(ctx, refreshKeyPrefix+claims.ID)
+
+	nc.Publish(m.Reply, []byte(`{"status": "Logged out"}`))
+}
+
+func handleLogoutAll(m *nats.Msg) {
+	var request struct {
+		AccessToken string `json:"accessToken"`
+	}
+	err := json.Unmarshal(m.Data, &request)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Invalid data: %v"}`, err)))
+		return
+	}
+
+	claims, err := token.Verify(jwtSecret, request.AccessToken)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(`{"error": "Invalid access token"}`))
+		return
+	}
+
+	if err := revokeUser(claims.UserID); err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Redis error: %v"}`, err)))
+		return
+	}
+
+	nc.Publish(m.Reply, []byte(`{"status": "Logged out from all sessions"}`))
+}
+
+// rehashPassword upgrades a legacy or outdated hash after a successful login.
+// Failures are only logged: the user is already authenticated.
+func rehashPassword(user models.User, plaintext string) {
+	newHash, err := password.Hash(plaintext)
+	if err != nil {
+		log.Printf("Failed to rehash password for %s: %v", user.Username, err)
+		return
+	}
+
+	request, err := json.Marshal(map[string]string{
+		"username":     user.Username,
+		"previousHash": user.Password,
+		"passwordHash": newHash,
+	})
+	if err != nil {
+		log.Printf("Failed to serialize rehash request for %s: %v", user.Username, err)
+		return
+	}
+
+	response, err := nc.Request("users.rehash", request, nats.DefaultTimeout)
+	if err != nil {
+		log.Printf("Users rehash service error for %s: %v", user.Username, err)
+		return
+	}
+
+	log.Printf("Rehash for %s: %s", user.Username, string(response.Data))
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/maksymshtarkberg/music-player-go/pkg/token"
+)
+
+const (
+	refreshKeyPrefix       = "auth:refresh:"
+	revokedFamilyKeyPrefix = "auth:revoked:family:"
+	revokedUserKeyPrefix   = "auth:revoked:user:"
+
+	// legacyCutoffLimit tells user revocations recorded in Unix seconds, before
+	// they were kept in milliseconds, from current ones.
+	legacyCutoffLimit = 100_000_000_000
+)
+
+var (
+	errRefreshReused  = errors.New("refresh token reuse detected")
+	errRefreshRevoked = errors.New("refresh token revoked")
+)
+
+func issueSession(userID, username string, roles []string, family string) (map[string]interface{}, error) {
+	pair, err := token.IssuePair(jwtSecret, userID, username, roles, family)
+	if err != nil {
+		return nil, err
+	}
+
+	err = redisClient.Set(ctx, refreshKeyPrefix+pair.RefreshID, pair.Family, time.Until(pair.RefreshExpiresAt)).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %v", err)
+	}
+
+	return map[string]interface{}{
+		"token":            pair.AccessToken,
+		"expiresAt":        pair.AccessExpiresAt.Format(time.RFC3339),
+		"refreshToken":     pair.RefreshToken,
+		"refreshExpiresAt": pair.RefreshExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+// consumeRefresh marks a refresh token as used. A token can be consumed exactly once;
+// presenting it again means it was stolen, so the whole family is revoked.
+func consumeRefresh(claims *token.Claims) error {
+	if err := checkRevoked(claims); err != nil {
+		return err
+	}
+
+	_, err := redisClient.GetDel(ctx, refreshKeyPrefix+claims.ID).Result()
+	if err == redis.Nil {
+		if err := revokeFamily(claims.Family); err != nil {
+			return err
+		}
+		return errRefreshReused
+	}
+	if err != nil {
+		return fmt.Errorf("failed to consume refresh token: %v", err)
+	}
+
+	return nil
+}
+
+func checkRevoked(claims *token.Claims) error {
+	revoked, err := redisClient.Exists(ctx, revokedFamilyKeyPrefix+claims.Family).Result()
+	if err != nil {
+		return fmt.Errorf("failed to check revocation: %v", err)
+	}
+	if revoked > 0 {
+		return errRefreshRevoked
+	}
+
+	cutoff, err := redisClient.Get(ctx, revokedUserKeyPrefix+claims.UserID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check revocation: %v", err)
+	}
+
+	cutoffMilli, err := strconv.ParseInt(cutoff, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid revocation record: %v", err)
+	}
+	if cutoffMilli < legacyCutoffLimit {
+		// Recorded in seconds: revoke everything up to the end of that second.
+		cutoffMilli = (cutoffMilli+1)*1000 - 1
+	}
+	// Tokens issued in the same millisecond as the revocation stay valid, so a
+	// login right after logging out everywhere is not revoked with the rest.
+	if claims.IssuedAt == nil || claims.IssuedAt.UnixMilli() < cutoffMilli {
+		return errRefreshRevoked
+	}
+
+	return nil
+}
+
+func revokeFamily(family string) error {
+	err := redisClient.Set(ctx, revokedFamilyKeyPrefix+family, time.Now().Unix(), token.RefreshTokenTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke token family: %v", err)
+	}
+	return nil
+}
+
+// revokeUser invalidates every refresh token issued to the user up to now.
+func revokeUser(userID string) error {
+	err := redisClient.Set(ctx, revokedUserKeyPrefix+userID, time.Now().UnixMilli(), token.RefreshTokenTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to revoke user sessions: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/maksymshtarkberg/music-player-go/pkg/token"
+)
+
+const songServicePrefix = "/main.SongService/"
+
+type claimsContextKey struct{}
+
+type bearerContextKey struct{}
+
+// authenticatedStream overrides the context of a server stream so handlers see the caller.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func UnaryAuthInterceptor(secret []byte) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, songServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func StreamAuthInterceptor(secret []byte) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, songServicePrefix) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), secret)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// ClaimsFromContext returns the caller authenticated by the interceptors.
+func ClaimsFromContext(ctx context.Context) (*token.Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey{}).(*token.Claims)
+	return claims, ok
+}
+
+// BearerFromContext returns the raw access token so it can be forwarded to NATS services.
+func BearerFromContext(ctx context.Context) string {
+	bearer, _ := ctx.Value(bearerContextKey{}).(string)
+	return bearer
+}
+
+func authenticate(ctx context.Context, secret []byte) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+
+	tokenString, err := token.FromAuthorizationHeader(values[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization header")
+	}
+
+	claims, err := token.Verify(secret, tokenString)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	ctx = context.WithValue(ctx, claimsContextKey{}, claims)
+	return context.WithValue(ctx, bearerContextKey{}, tokenString), nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+const blobsCollection = storage.BlobsCollection
+
+// blobRecord is the reference-counted index entry of a GridFS file, keyed by
+// the SHA-256 of its content. Songs and covers with identical content share
+// one file; it is deleted when the last reference is released.
+type blobRecord struct {
+	SHA256    string             `bson:"_id"`
+	FileID    primitive.ObjectID `bson:"fileId"`
+	Size      int64              `bson:"size"`
+	MimeType  string             `bson:"mimeType"`
+	Refs      int64              `bson:"refs"`
+	CreatedAt time.Time          `bson:"createdAt"`
+}
+
+func ensureBlobIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(blobsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "fileId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// acquireBlob takes a reference on the content of a freshly stored file. If
+// the same content is already stored, the new file is deleted and the
+// returned info points at the existing one.
+func (s *Server) acquireBlob(ctx context.Context, bucket *gridfs.Bucket, info blobInfo) (blobInfo, error) {
+	blobs := s.mongoClient.Database("musicDB").Collection(blobsCollection)
+
+	for {
+		var existing blobRecord
+		err := blobs.FindOneAndUpdate(ctx,
+			bson.M{"_id": info.SHA256},
+			bson.M{"$inc": bson.M{"refs": 1}},
+		).Decode(&existing)
+		if err == nil {
+			if existing.FileID != info.ID {
+				s.deleteBlobFiles(ctx, bucket, info.ID)
+			}
+			info.ID = existing.FileID
+			return info, nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return blobInfo{}, status.Errorf(codes.Internal, "failed to look up blob: %v", err)
+		}
+
+		_, err = blobs.InsertOne(ctx, blobRecord{
+			SHA256:    info.SHA256,
+			FileID:    info.ID,
+			Size:      info.Size,
+			MimeType:  info.MimeType,
+			Refs:      1,
+			CreatedAt: time.Now(),
+		})
+		if err == nil {
+			return info, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return blobInfo{}, status.Errorf(codes.Internal, "failed to index blob: %v", err)
+		}
+		// Someone stored the same content concurrently; take a reference on theirs.
+	}
+}
+
+// releaseBlob drops a reference on a GridFS file and deletes the file, its
+// thumbnails and its waveform once nothing refers to it. Files stored before
+// the blob index existed have no entry and are deleted directly.
+func (s *Server) releaseBlob(ctx context.Context, bucket *gridfs.Bucket, fileID primitive.ObjectID) error {
+	blobs := s.mongoClient.Database("musicDB").Collection(blobsCollection)
+
+	var record blobRecord
+	err := blobs.FindOneAndUpdate(ctx,
+		bson.M{"fileId": fileID},
+		bson.M{"$inc": bson.M{"refs": -1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&record)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return s.deleteBlobFiles(ctx, bucket, fileID)
+	}
+	if err != nil {
+		return err
+	}
+	if record.Refs > 0 {
+		return nil
+	}
+
+	// Only delete if no acquireBlob took a new reference in the meantime.
+	deleted, err := blobs.DeleteOne(ctx, bson.M{"_id": record.SHA256, "fileId": fileID, "refs": bson.M{"$lte": 0}})
+	if err != nil {
+		return err
+	}
+	if deleted.DeletedCount == 0 {
+		return nil
+	}
+	return s.deleteBlobFiles(ctx, bucket, fileID)
+}
+
+// deleteBlobFiles removes a GridFS file and everything derived from it.
+func (s *Server) deleteBlobFiles(ctx context.Context, bucket *gridfs.Bucket, fileID primitive.ObjectID) error {
+	if err := storage.DeleteThumbnails(ctx, bucket, fileID); err != nil {
+		return err
+	}
+	if _, err := s.mongoClient.Database("musicDB").Collection(waveformsCollection).DeleteOne(ctx, bson.M{"_id": fileID}); err != nil {
+		log.Printf("Failed to delete waveform of %s: %v", fileID.Hex(), err)
+	}
+	if err := bucket.DeleteContext(ctx, fileID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
+
+// acquireSongBlobs acquires a freshly stored song and cover, releasing the song
+// again if the cover cannot be acquired.
+func (s *Server) acquireSongBlobs(ctx context.Context, bucket *gridfs.Bucket, song, cover blobInfo) (blobInfo, blobInfo, error) {
+	acquiredSong, err := s.acquireBlob(ctx, bucket, song)
+	if err != nil {
+		s.deleteBlobFiles(ctx, bucket, song.ID)
+		s.deleteBlobFiles(ctx, bucket, cover.ID)
+		return blobInfo{}, blobInfo{}, err
+	}
+
+	acquiredCover, err := s.acquireBlob(ctx, bucket, cover)
+	if err != nil {
+		s.releaseBlob(ctx, bucket, acquiredSong.ID)
+		s.deleteBlobFiles(ctx, bucket, cover.ID)
+		return blobInfo{}, blobInfo{}, err
+	}
+
+	return acquiredSong, acquiredCover, nil
+}
+
+// publishStoredSong publishes a song whose song and cover blobs have been
+// acquired. cover is the cover acquired before inspection, which an embedded
+// picture may have replaced. On success the replaced cover is released; on
+// failure the references taken for this song are, except for cover when
+// keepCover is set because the caller still owns it. A write that is queued
+// but not yet confirmed counts as success here, since the queued song still
+// refers to its blobs.
+func (s *Server) publishStoredSong(ctx context.Context, bucket *gridfs.Bucket, metadata *pb.SongMetadata, cover primitive.ObjectID, keepCover bool) error {
+	replaced := metadata.AlbumCoverID != cover.Hex()
+
+	err := s.publishSongMetadata(ctx, metadata)
+	if err == nil || err == errIngestPending {
+		if replaced {
+			s.releaseBlobHex(ctx, bucket, cover.Hex())
+		}
+		return err
+	}
+
+	s.releaseBlobHex(ctx, bucket, metadata.SongFileID)
+	if replaced || !keepCover {
+		s.releaseBlobHex(ctx, bucket, metadata.AlbumCoverID)
+	}
+	if replaced && !keepCover {
+		s.releaseBlobHex(ctx, bucket, cover.Hex())
+	}
+	return err
+}
+
+func (s *Server) releaseBlobHex(ctx context.Context, bucket *gridfs.Bucket, id string) {
+	fileID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return
+	}
+	if err := s.releaseBlob(ctx, bucket, fileID); err != nil {
+		log.Printf("Failed to release blob %s: %v", id, err)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/media"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+// coverSizes maps the sizes clients can request onto storage.ThumbnailSizes.
+var coverSizes = map[pb.CoverSize]int{
+	pb.CoverSize_COVER_SIZE_SMALL:  64,
+	pb.CoverSize_COVER_SIZE_MEDIUM: 256,
+	pb.CoverSize_COVER_SIZE_LARGE:  640,
+}
+
+// processAlbumCover validates a stored cover and generates its thumbnails.
+// Empty covers are allowed: songs do not need one. The size and content type
+// are checked from the file document before anything is read, so a cover that
+// got past the upload limits some other way is never loaded into memory.
+func processAlbumCover(bucket *gridfs.Bucket, coverID primitive.ObjectID) error {
+	downloadStream, err := bucket.OpenDownloadStream(coverID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read album cover: %v", err)
+	}
+	defer downloadStream.Close()
+
+	file := downloadStream.GetFile()
+	if file.Length == 0 {
+		return nil
+	}
+	if file.Length > maxAlbumCoverSize {
+		return &uploadRejection{
+			Kind:   coverBlob.name,
+			Reason: fmt.Sprintf("album cover exceeds %d bytes", maxAlbumCoverSize),
+			Size:   file.Length,
+		}
+	}
+	contentType, err := storage.DetectContentType(bucket, file)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to read album cover: %v", err)
+	}
+	if !strings.HasPrefix(contentType, "image/") {
+		return &uploadRejection{
+			Kind:     coverBlob.name,
+			Reason:   fmt.Sprintf("album cover has content type %s, not an image", contentType),
+			MimeType: contentType,
+			Size:     file.Length,
+		}
+	}
+
+	var data bytes.Buffer
+	if _, err := data.ReadFrom(io.LimitReader(downloadStream, maxAlbumCoverSize)); err != nil {
+		return status.Errorf(codes.Internal, "failed to read album cover: %v", err)
+	}
+
+	err = storage.StoreThumbnails(bucket, coverID, data.Bytes())
+	if errors.Is(err, media.ErrInvalidImage) {
+		return &uploadRejection{
+			Kind:   coverBlob.name,
+			Reason: fmt.Sprintf("album cover is %v", err),
+			Size:   int64(data.Len()),
+			Head:   data.Bytes()[:min(data.Len(), storage.SniffLen)],
+		}
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to generate album cover thumbnails: %v", err)
+	}
+	return nil
+}
+
+// coverVariant picks the GridFS file to stream for the requested size. Covers
+// stored before thumbnails existed get them generated on first request; if
+// that is impossible the original is served. Callers must have checked that
+// coverID is a song's cover, or any file could be fed to the image decoder.
+func coverVariant(ctx context.Context, bucket *gridfs.Bucket, coverID primitive.ObjectID, size pb.CoverSize) (primitive.ObjectID, error) {
+	pixels, ok := coverSizes[size]
+	if !ok {
+		return coverID, nil
+	}
+
+	thumbnailID, err := storage.FindThumbnail(ctx, bucket, coverID, pixels)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		if err := processAlbumCover(bucket, coverID); err != nil {
+			return coverID, nil
+		}
+		thumbnailID, err = storage.FindThumbnail(ctx, bucket, coverID, pixels)
+	}
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return coverID, nil
+	}
+	return thumbnailID, err
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+const (
+	songDeletionsCollection = "song_deletions"
+
+	songDeletionSweepTick = time.Minute
+	// The worker leaves a deletion to the request driving it for this long.
+	// A deletion still in deletionStarted after that has lost its songs.purge
+	// request or reply, and is resolved from the songs collection.
+	songDeletionStaleAfter = 2 * time.Minute
+	// Finished deletions are kept this long for inspection.
+	songDeletionRetention = 7 * 24 * time.Hour
+
+	maxSongDeletionBackoff = time.Hour
+)
+
+// Steps of a song deletion. A deletion moves from deletionStarted to
+// deletionDocumentDeleted once the song document is gone, and ends in
+// deletionCompleted once both blobs are released. If the document could not
+// be deleted it ends in deletionCompensated and the blobs are left alone.
+const (
+	deletionStarted         = "started"
+	deletionDocumentDeleted = "document_deleted"
+	deletionCompleted       = "completed"
+	deletionCompensated     = "compensated"
+)
+
+// songDeletion is the persisted state of one song deletion, keyed by the song
+// ID. The file IDs are copied from the stored song before its document is
+// deleted, so the blobs can still be released after a crash.
+type songDeletion struct {
+	ID                 primitive.ObjectID `bson:"_id"`
+	UserID             string             `bson:"userId"`
+	SongFileID         string             `bson:"songFileID"`
+	AlbumCoverID       string             `bson:"albumCoverID"`
+	State              string             `bson:"state"`
+	SongFileReleased   bool               `bson:"songFileReleased"`
+	AlbumCoverReleased bool               `bson:"albumCoverReleased"`
+	Attempts           int                `bson:"attempts"`
+	LastError          string             `bson:"lastError,omitempty"`
+	CreatedAt          time.Time          `bson:"createdAt"`
+	NextAttemptAt      time.Time          `bson:"nextAttemptAt"`
+	ExpiresAt          *time.Time         `bson:"expiresAt,omitempty"`
+}
+
+func ensureSongDeletionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(songDeletionsCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "state", Value: 1}, {Key: "nextAttemptAt", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	})
+	return err
+}
+
+// PurgeSong permanently deletes a trashed song. The file IDs are read from the
+// stored song and recorded before its document is deleted, so the files are
+// released even if this request does not get that far.
+func (s *Server) PurgeSong(ctx context.Context, req *pb.PurgeSongRequest) (*pb.PurgeSongResponse, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	songID, err := primitive.ObjectIDFromHex(req.GetSongId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid song ID")
+	}
+
+	song, err := findTrashedSong(ctx, s.mongoClient.Database("musicDB").Collection(storage.SongsCollection), songID)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := s.startSongDeletion(ctx, songID, claims.UserID, song.SongFileID, song.AlbumCoverID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := natsrpc.Request(ctx, s.natsConn, "songs.purge", []byte(req.GetSongId()), BearerFromContext(ctx), 10*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			// The songs service refused; nothing has been deleted.
+			s.finishSongDeletion(ctx, deletion, deletionCompensated, err)
+			return nil, err
+		}
+		// The outcome is unknown; the worker resolves it once the deletion is stale.
+		return nil, status.Errorf(codes.Unavailable, "failed to request song deletion: %v", err)
+	}
+
+	var response pb.PurgeSongResponse
+	if err := proto.Unmarshal(msg.Data, &response); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal response: %v", err)
+	}
+	if !response.GetSuccess() {
+		s.finishSongDeletion(ctx, deletion, deletionCompensated, errors.New(response.GetMessage()))
+		return &response, nil
+	}
+
+	s.completeSongDeletion(ctx, deletion)
+	return &response, nil
+}
+
+// trashedSongRefs are the files of a trashed song.
+type trashedSongRefs struct {
+	SongFileID   string             `bson:"songFileID"`
+	AlbumCoverID string             `bson:"albumCoverID"`
+	DeletedAt    primitive.DateTime `bson:"deletedAt"`
+}
+
+// findTrashedSong reads a song's files straight from the songs collection, as
+// purgeExpiredTrash does: songs.get does not return trashed songs. Whether
+// the caller may purge it is left to songs.purge.
+func findTrashedSong(ctx context.Context, songs *mongo.Collection, songID primitive.ObjectID) (*trashedSongRefs, error) {
+	var song trashedSongRefs
+	err := songs.FindOne(ctx, bson.M{"_id": songID},
+		options.FindOne().SetProjection(bson.M{"songFileID": 1, "albumCoverID": 1, "deletedAt": 1}),
+	).Decode(&song)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Error(codes.NotFound, "song not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get song: %v", err)
+	}
+	if song.DeletedAt == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "song is not in the trash")
+	}
+	return &song, nil
+}
+
+// startSongDeletion records that the document of songID is about to be
+// deleted, together with the files it refers to.
+func (s *Server) startSongDeletion(ctx context.Context, songID primitive.ObjectID, userID, songFileID, albumCoverID string) (*songDeletion, error) {
+	now := time.Now()
+	deletion := &songDeletion{
+		ID:            songID,
+		UserID:        userID,
+		SongFileID:    songFileID,
+		AlbumCoverID:  albumCoverID,
+		State:         deletionStarted,
+		CreatedAt:     now,
+		NextAttemptAt: now.Add(songDeletionStaleAfter),
+	}
+
+	deletions := s.mongoClient.Database("musicDB").Collection(songDeletionsCollection)
+	// A finished deletion of the same song may still be retained, e.g. one
+	// that was compensated because the song was restored.
+	if _, err := deletions.DeleteOne(ctx, bson.M{"_id": songID, "state": bson.M{"$in": bson.A{deletionCompleted, deletionCompensated}}}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record song deletion: %v", err)
+	}
+	if _, err := deletions.InsertOne(ctx, deletion); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, status.Error(codes.Aborted, "song is already being deleted")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to record song deletion: %v", err)
+	}
+	return deletion, nil
+}
+
+// completeSongDeletion continues a deletion whose song document is gone.
+// Failures are left to the worker; the song is gone for the client either way.
+func (s *Server) completeSongDeletion(ctx context.Context, deletion *songDeletion) {
+	if err := s.advanceSongDeletion(ctx, deletion, deletionDocumentDeleted); err != nil {
+		log.Printf("Failed to record deletion of song %s: %v", deletion.ID.Hex(), err)
+	}
+	if err := s.releaseSongDeletionBlobs(ctx, deletion); err != nil {
+		log.Printf("Failed to release blobs of deleted song %s, will retry: %v", deletion.ID.Hex(), err)
+	}
+}
+
+// advanceSongDeletion moves deletion to state. The worker only picks it up if
+// the caller does not finish it.
+func (s *Server) advanceSongDeletion(ctx context.Context, deletion *songDeletion, state string) error {
+	deletion.State = state
+	deletion.NextAttemptAt = time.Now().Add(songDeletionStaleAfter)
+	_, err := s.mongoClient.Database("musicDB").Collection(songDeletionsCollection).UpdateOne(ctx,
+		bson.M{"_id": deletion.ID},
+		bson.M{"$set": bson.M{"state": state, "nextAttemptAt": deletion.NextAttemptAt}},
+	)
+	return err
+}
+
+// finishSongDeletion ends deletion in a final state and lets it expire.
+func (s *Server) finishSongDeletion(ctx context.Context, deletion *songDeletion, state string, cause error) {
+	expiresAt := time.Now().Add(songDeletionRetention)
+	update := bson.M{"state": state, "expiresAt": expiresAt}
+	if cause != nil {
+		update["lastError"] = cause.Error()
+	}
+
+	deletion.State = state
+	deletion.ExpiresAt = &expiresAt
+	if _, err := s.mongoClient.Database("musicDB").Collection(songDeletionsCollection).UpdateOne(ctx,
+		bson.M{"_id": deletion.ID},
+		bson.M{"$set": update},
+	); err != nil {
+		log.Printf("Failed to record %s deletion of song %s: %v", state, deletion.ID.Hex(), err)
+	}
+}
+
+// releaseSongDeletionBlobs releases the song file and cover of a deleted song.
+// Each release is recorded as it happens so a retry does not release a shared
+// blob twice. On failure the deletion is rescheduled with backoff.
+func (s *Server) releaseSongDeletionBlobs(ctx context.Context, deletion *songDeletion) error {
+	db := s.mongoClient.Database("musicDB")
+	deletions := db.Collection(songDeletionsCollection)
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return s.retrySongDeletion(ctx, deletion, fmt.Errorf("failed to open GridFS bucket: %v", err))
+	}
+
+	steps := []struct {
+		id       string
+		released *bool
+		field    string
+	}{
+		{deletion.SongFileID, &deletion.SongFileReleased, "songFileReleased"},
+		{deletion.AlbumCoverID, &deletion.AlbumCoverReleased, "albumCoverReleased"},
+	}
+	for _, step := range steps {
+		if *step.released {
+			continue
+		}
+		// IDs that do not parse never referred to a blob.
+		if fileID, err := primitive.ObjectIDFromHex(step.id); err == nil {
+			if err := s.releaseBlob(ctx, bucket, fileID); err != nil {
+				return s.retrySongDeletion(ctx, deletion, fmt.Errorf("failed to release blob %s: %v", step.id, err))
+			}
+		}
+		if _, err := deletions.UpdateOne(ctx, bson.M{"_id": deletion.ID}, bson.M{"$set": bson.M{step.field: true}}); err != nil {
+			return s.retrySongDeletion(ctx, deletion, fmt.Errorf("failed to record release of blob %s: %v", step.id, err))
+		}
+		*step.released = true
+	}
+
+	s.finishSongDeletion(ctx, deletion, deletionCompleted, nil)
+	return nil
+}
+
+// retrySongDeletion records a failed attempt and backs off exponentially.
+func (s *Server) retrySongDeletion(ctx context.Context, deletion *songDeletion, cause error) error {
+	deletion.Attempts++
+	backoff := time.Duration(1<<min(deletion.Attempts, 12)) * time.Second
+	if backoff > maxSongDeletionBackoff {
+		backoff = maxSongDeletionBackoff
+	}
+	deletion.NextAttemptAt = time.Now().Add(backoff)
+
+	if _, err := s.mongoClient.Database("musicDB").Collection(songDeletionsCollection).UpdateOne(ctx,
+		bson.M{"_id": deletion.ID},
+		bson.M{"$set": bson.M{
+			"attempts":      deletion.Attempts,
+			"lastError":     cause.Error(),
+			"nextAttemptAt": deletion.NextAttemptAt,
+		}},
+	); err != nil {
+		log.Printf("Failed to reschedule deletion of song %s: %v", deletion.ID.Hex(), err)
+	}
+	return cause
+}
+
+// runSongDeletionWorker periodically resumes deletions that did not finish in
+// the request that started them.
+func (s *Server) runSongDeletionWorker(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.resumeSongDeletions(); err != nil {
+			log.Printf("Song deletion worker failed: %v", err)
+		}
+	}
+}
+
+func (s *Server) resumeSongDeletions() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	db := s.mongoClient.Database("musicDB")
+	cursor, err := db.Collection(songDeletionsCollection).Find(ctx, bson.M{
+		"state":         bson.M{"$in": bson.A{deletionStarted, deletionDocumentDeleted}},
+		"nextAttemptAt": bson.M{"$lte": time.Now()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find pending song deletions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var deletion songDeletion
+		if err := cursor.Decode(&deletion); err != nil {
+			return fmt.Errorf("failed to decode song deletion: %v", err)
+		}
+
+		if deletion.State == deletionStarted {
+			// The songs.purge request was lost or its reply was; the songs
+			// collection tells which.
+			err := db.Collection(storage.SongsCollection).FindOne(ctx, bson.M{"_id": deletion.ID}).Err()
+			switch {
+			case err == nil:
+				s.finishSongDeletion(ctx, &deletion, deletionCompensated, errors.New("song document was not deleted"))
+				continue
+			case !errors.Is(err, mongo.ErrNoDocuments):
+				s.retrySongDeletion(ctx, &deletion, fmt.Errorf("failed to look up song: %v", err))
+				continue
+			}
+			if err := s.advanceSongDeletion(ctx, &deletion, deletionDocumentDeleted); err != nil {
+				log.Printf("Failed to record deletion of song %s: %v", deletion.ID.Hex(), err)
+				continue
+			}
+		}
+
+		if err := s.releaseSongDeletionBlobs(ctx, &deletion); err != nil {
+			log.Printf("Failed to release blobs of deleted song %s: %v", deletion.ID.Hex(), err)
+		}
+	}
+	return cursor.Err()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/songs/repository"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+// mongoTestURIEnv points the tests at a MongoDB server; tests that need one
+// are skipped without it.
+const mongoTestURIEnv = "MONGO_TEST_URI"
+
+// newMongoTestDatabase returns a throwaway database, skipping the test if no
+// server is configured.
+func newMongoTestDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+
+	uri := os.Getenv(mongoTestURIEnv)
+	if uri == "" {
+		t.Skipf("%s is not set", mongoTestURIEnv)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	db := client.Database("grpc_server_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+	return db
+}
+
+// TestPurgeTrashedSong follows PurgeSong through a song the songs service
+// trashed and then purges: the lookup must find it although songs.get no
+// longer returns it.
+func TestPurgeTrashedSong(t *testing.T) {
+	db := newMongoTestDatabase(t)
+	ctx := context.Background()
+	songs := repository.NewMongoSongRepository(db)
+	collection := db.Collection(storage.SongsCollection)
+
+	song := &models.Song{
+		ID:           primitive.NewObjectID(),
+		Title:        "Song",
+		SongFileID:   primitive.NewObjectID().Hex(),
+		AlbumCoverID: primitive.NewObjectID().Hex(),
+	}
+	if err := songs.Create(ctx, song); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if _, err := findTrashedSong(ctx, collection, song.ID); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("findTrashedSong of a live song error = %v, want %v", err, codes.FailedPrecondition)
+	}
+
+	if err := songs.Trash(ctx, song.ID, time.Now()); err != nil {
+		t.Fatalf("Trash failed: %v", err)
+	}
+	refs, err := findTrashedSong(ctx, collection, song.ID)
+	if err != nil {
+		t.Fatalf("findTrashedSong of a trashed song failed: %v", err)
+	}
+	if refs.SongFileID != song.SongFileID || refs.AlbumCoverID != song.AlbumCoverID {
+		t.Errorf("findTrashedSong = %+v, want files %s and %s", refs, song.SongFileID, song.AlbumCoverID)
+	}
+
+	// songs.purge, which PurgeSong sends next.
+	if err := songs.Purge(ctx, song.ID); err != nil {
+		t.Fatalf("Purge failed: %v", err)
+	}
+	if _, err := findTrashedSong(ctx, collection, song.ID); status.Code(err) != codes.NotFound {
+		t.Errorf("findTrashedSong of a purged song error = %v, want %v", err, codes.NotFound)
+	}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/media"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+// inspectSong completes the metadata of a freshly stored song from the file
+// itself: embedded tags and the audio stream parameters. Problems reading the
+// file never fail the upload.
+func (s *Server) inspectSong(ctx context.Context, bucket *gridfs.Bucket, metadata *pb.SongMetadata) {
+	songFileID, err := primitive.ObjectIDFromHex(metadata.SongFileID)
+	if err != nil {
+		return
+	}
+
+	reader, err := storage.NewReadSeeker(bucket, songFileID)
+	if err != nil {
+		log.Printf("Failed to open song %s for inspection: %v", metadata.SongFileID, err)
+		return
+	}
+	defer reader.Close()
+
+	s.applyTags(ctx, bucket, reader, metadata)
+	applyAudioInfo(reader, metadata)
+}
+
+func applyAudioInfo(reader *storage.ReadSeeker, metadata *pb.SongMetadata) {
+	info, err := media.Probe(reader, reader.File().Length)
+	if errors.Is(err, media.ErrUnsupportedFormat) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to probe song %s: %v", metadata.SongFileID, err)
+		return
+	}
+
+	metadata.DurationMs = info.Duration.Milliseconds()
+	metadata.SampleRate = int32(info.SampleRate)
+	metadata.Channels = int32(info.Channels)
+	metadata.Bitrate = int32(info.Bitrate)
+}
+
+// applyTags fills empty metadata fields from the embedded tags and promotes an
+// embedded picture to the album cover when none was uploaded.
+func (s *Server) applyTags(ctx context.Context, bucket *gridfs.Bucket, reader *storage.ReadSeeker, metadata *pb.SongMetadata) {
+	tags, err := media.ReadTags(reader)
+	if errors.Is(err, media.ErrNoTags) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to read tags from song %s: %v", metadata.SongFileID, err)
+		return
+	}
+
+	if metadata.Title == "" {
+		metadata.Title = tags.Title
+	}
+	if metadata.Artist == "" {
+		metadata.Artist = tags.Artist
+	}
+	if metadata.Album == "" {
+		metadata.Album = tags.Album
+	}
+	metadata.TrackNumber = int32(tags.Track)
+	metadata.Year = int32(tags.Year)
+	metadata.Genre = tags.Genre
+
+	if metadata.AlbumCoverSize > 0 || tags.Picture == nil {
+		return
+	}
+
+	cover, err := uploadBlob(bucket, coverBlob, tags.Picture.Data, maxAlbumCoverSize)
+	if err != nil {
+		log.Printf("Failed to store embedded cover of song %s: %v", metadata.SongFileID, err)
+		return
+	}
+	if err := processAlbumCover(bucket, cover.ID); err != nil {
+		log.Printf("Ignoring embedded cover of song %s: %v", metadata.SongFileID, err)
+		s.deleteBlobFiles(ctx, bucket, cover.ID)
+		return
+	}
+	acquired, err := s.acquireBlob(ctx, bucket, cover)
+	if err != nil {
+		log.Printf("Failed to index embedded cover of song %s: %v", metadata.SongFileID, err)
+		s.deleteBlobFiles(ctx, bucket, cover.ID)
+		return
+	}
+
+	// The reference on the replaced empty cover is dropped by publishStoredSong.
+	metadata.AlbumCoverID = acquired.ID.Hex()
+	metadata.AlbumCoverSize = acquired.Size
+	metadata.AlbumCoverMimeType = acquired.MimeType
+	metadata.AlbumCoverSha256 = acquired.SHA256
+}
@@ -9,6 +9,7 @@ package __
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +21,114 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CoverSize int32
+
+const (
+	// The cover as uploaded.
+	CoverSize_COVER_SIZE_ORIGINAL CoverSize = 0
+	// 64x64 JPEG.
+	CoverSize_COVER_SIZE_SMALL CoverSize = 1
+	// 256x256 JPEG.
+	CoverSize_COVER_SIZE_MEDIUM CoverSize = 2
+	// 640x640 JPEG.
+	CoverSize_COVER_SIZE_LARGE CoverSize = 3
+)
+
+// Enum value maps for CoverSize.
+var (
+	CoverSize_name = map[int32]string{
+		0: "COVER_SIZE_ORIGINAL",
+		1: "COVER_SIZE_SMALL",
+		2: "COVER_SIZE_MEDIUM",
+		3: "COVER_SIZE_LARGE",
+	}
+	CoverSize_value = map[string]int32{
+		"COVER_SIZE_ORIGINAL": 0,
+		"COVER_SIZE_SMALL":    1,
+		"COVER_SIZE_MEDIUM":   2,
+		"COVER_SIZE_LARGE":    3,
+	}
+)
+
+func (x CoverSize) Enum() *CoverSize {
+	p := new(CoverSize)
+	*p = x
+	return p
+}
+
+func (x CoverSize) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CoverSize) Descriptor() protoreflect.EnumDescriptor {
+	return file_songs_proto_enumTypes[0].Descriptor()
+}
+
+func (CoverSize) Type() protoreflect.EnumType {
+	return &file_songs_proto_enumTypes[0]
+}
+
+func (x CoverSize) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CoverSize.Descriptor instead.
+func (CoverSize) EnumDescriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{0}
+}
+
+type SongSortField int32
+
+const (
+	SongSortField_SONG_SORT_FIELD_UNSPECIFIED SongSortField = 0
+	SongSortField_SONG_SORT_FIELD_TITLE       SongSortField = 1
+	SongSortField_SONG_SORT_FIELD_ARTIST      SongSortField = 2
+	SongSortField_SONG_SORT_FIELD_UPLOADED_AT SongSortField = 3
+)
+
+// Enum value maps for SongSortField.
+var (
+	SongSortField_name = map[int32]string{
+		0: "SONG_SORT_FIELD_UNSPECIFIED",
+		1: "SONG_SORT_FIELD_TITLE",
+		2: "SONG_SORT_FIELD_ARTIST",
+		3: "SONG_SORT_FIELD_UPLOADED_AT",
+	}
+	SongSortField_value = map[string]int32{
+		"SONG_SORT_FIELD_UNSPECIFIED": 0,
+		"SONG_SORT_FIELD_TITLE":       1,
+		"SONG_SORT_FIELD_ARTIST":      2,
+		"SONG_SORT_FIELD_UPLOADED_AT": 3,
+	}
+)
+
+func (x SongSortField) Enum() *SongSortField {
+	p := new(SongSortField)
+	*p = x
+	return p
+}
+
+func (x SongSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SongSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_songs_proto_enumTypes[1].Descriptor()
+}
+
+func (SongSortField) Type() protoreflect.EnumType {
+	return &file_songs_proto_enumTypes[1]
+}
+
+func (x SongSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SongSortField.Descriptor instead.
+func (SongSortField) EnumDescriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{1}
+}
+
 type UploadSongRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -29,9 +138,12 @@ type UploadSongRequest struct {
 	Artist      string `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
 	Album       string `protobuf:"bytes,3,opt,name=album,proto3" json:"album,omitempty"`
 	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	UploadedBy  string `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
-	SongFile    []byte `protobuf:"bytes,6,opt,name=song_file,json=songFile,proto3" json:"song_file,omitempty"`
-	AlbumCover  []byte `protobuf:"bytes,7,opt,name=album_cover,json=albumCover,proto3" json:"album_cover,omitempty"`
+	// Ignored: the uploader is taken from the authenticated caller.
+	//
+	// Deprecated: Marked as deprecated in songs.proto.
+	UploadedBy string `protobuf:"bytes,5,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	SongFile   []byte `protobuf:"bytes,6,opt,name=song_file,json=songFile,proto3" json:"song_file,omitempty"`
+	AlbumCover []byte `protobuf:"bytes,7,opt,name=album_cover,json=albumCover,proto3" json:"album_cover,omitempty"`
 }
 
 func (x *UploadSongRequest) Reset() {
@@ -94,6 +206,7 @@ func (x *UploadSongRequest) GetDescription() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in songs.proto.
 func (x *UploadSongRequest) GetUploadedBy() string {
 	if x != nil {
 		return x.UploadedBy
@@ -186,16 +299,22 @@ func (x *UploadSongResponse) GetCoverId() string {
 	return ""
 }
 
-type StreamSongFileRequest struct {
+// The first message must carry metadata; every following message carries a chunk
+// of the song file or the album cover.
+type UploadSongStreamRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SongFileId string `protobuf:"bytes,1,opt,name=song_file_id,json=songFileId,proto3" json:"song_file_id,omitempty"`
+	// Types that are assignable to Payload:
+	//	*UploadSongStreamRequest_Metadata
+	//	*UploadSongStreamRequest_SongChunk
+	//	*UploadSongStreamRequest_AlbumCoverChunk
+	Payload isUploadSongStreamRequest_Payload `protobuf_oneof:"payload"`
 }
 
-func (x *StreamSongFileRequest) Reset() {
-	*x = StreamSongFileRequest{}
+func (x *UploadSongStreamRequest) Reset() {
+	*x = UploadSongStreamRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_songs_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -203,13 +322,13 @@ func (x *StreamSongFileRequest) Reset() {
 	}
 }
 
-func (x *StreamSongFileRequest) String() string {
+func (x *UploadSongStreamRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamSongFileRequest) ProtoMessage() {}
+func (*UploadSongStreamRequest) ProtoMessage() {}
 
-func (x *StreamSongFileRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadSongStreamRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_songs_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -221,28 +340,78 @@ func (x *StreamSongFileRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamSongFileRequest.ProtoReflect.Descriptor instead.
-func (*StreamSongFileRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadSongStreamRequest.ProtoReflect.Descriptor instead.
+func (*UploadSongStreamRequest) Descriptor() ([]byte, []int) {
 	return file_songs_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *StreamSongFileRequest) GetSongFileId() string {
-	if x != nil {
-		return x.SongFileId
+func (m *UploadSongStreamRequest) GetPayload() isUploadSongStreamRequest_Payload {
+	if m != nil {
+		return m.Payload
 	}
-	return ""
+	return nil
 }
 
-type StreamSongFileResponse struct {
+func (x *UploadSongStreamRequest) GetMetadata() *UploadSongStreamMetadata {
+	if x, ok := x.GetPayload().(*UploadSongStreamRequest_Metadata); ok {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *UploadSongStreamRequest) GetSongChunk() []byte {
+	if x, ok := x.GetPayload().(*UploadSongStreamRequest_SongChunk); ok {
+		return x.SongChunk
+	}
+	return nil
+}
+
+func (x *UploadSongStreamRequest) GetAlbumCoverChunk() []byte {
+	if x, ok := x.GetPayload().(*UploadSongStreamRequest_AlbumCoverChunk); ok {
+		return x.AlbumCoverChunk
+	}
+	return nil
+}
+
+type isUploadSongStreamRequest_Payload interface {
+	isUploadSongStreamRequest_Payload()
+}
+
+type UploadSongStreamRequest_Metadata struct {
+	Metadata *UploadSongStreamMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadSongStreamRequest_SongChunk struct {
+	SongChunk []byte `protobuf:"bytes,2,opt,name=song_chunk,json=songChunk,proto3,oneof"`
+}
+
+type UploadSongStreamRequest_AlbumCoverChunk struct {
+	AlbumCoverChunk []byte `protobuf:"bytes,3,opt,name=album_cover_chunk,json=albumCoverChunk,proto3,oneof"`
+}
+
+func (*UploadSongStreamRequest_Metadata) isUploadSongStreamRequest_Payload() {}
+
+func (*UploadSongStreamRequest_SongChunk) isUploadSongStreamRequest_Payload() {}
+
+func (*UploadSongStreamRequest_AlbumCoverChunk) isUploadSongStreamRequest_Payload() {}
+
+type UploadSongStreamMetadata struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Title       string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string `protobuf:"bytes,2,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album       string `protobuf:"bytes,3,opt,name=album,proto3" json:"album,omitempty"`
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Total size of the song file in bytes.
+	SongSize int64 `protobuf:"varint,5,opt,name=song_size,json=songSize,proto3" json:"song_size,omitempty"`
+	// Hex-encoded SHA-256 of the complete song file, verified once the stream ends.
+	SongSha256 string `protobuf:"bytes,6,opt,name=song_sha256,json=songSha256,proto3" json:"song_sha256,omitempty"`
 }
 
-func (x *StreamSongFileResponse) Reset() {
-	*x = StreamSongFileResponse{}
+func (x *UploadSongStreamMetadata) Reset() {
+	*x = UploadSongStreamMetadata{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_songs_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -250,13 +419,13 @@ func (x *StreamSongFileResponse) Reset() {
 	}
 }
 
-func (x *StreamSongFileResponse) String() string {
+func (x *UploadSongStreamMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamSongFileResponse) ProtoMessage() {}
+func (*UploadSongStreamMetadata) ProtoMessage() {}
 
-func (x *StreamSongFileResponse) ProtoReflect() protoreflect.Message {
+func (x *UploadSongStreamMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_songs_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -268,90 +437,79 @@ func (x *StreamSongFileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamSongFileResponse.ProtoReflect.Descriptor instead.
-func (*StreamSongFileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadSongStreamMetadata.ProtoReflect.Descriptor instead.
+func (*UploadSongStreamMetadata) Descriptor() ([]byte, []int) {
 	return file_songs_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *StreamSongFileResponse) GetChunk() []byte {
+func (x *UploadSongStreamMetadata) GetTitle() string {
 	if x != nil {
-		return x.Chunk
+		return x.Title
 	}
-	return nil
-}
-
-type StreamAlbumCoverRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	AlbumCoverId string `protobuf:"bytes,1,opt,name=album_cover_id,json=albumCoverId,proto3" json:"album_cover_id,omitempty"`
+	return ""
 }
 
-func (x *StreamAlbumCoverRequest) Reset() {
-	*x = StreamAlbumCoverRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *UploadSongStreamMetadata) GetArtist() string {
+	if x != nil {
+		return x.Artist
 	}
+	return ""
 }
 
-func (x *StreamAlbumCoverRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UploadSongStreamMetadata) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
 }
 
-func (*StreamAlbumCoverRequest) ProtoMessage() {}
-
-func (x *StreamAlbumCoverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *UploadSongStreamMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use StreamAlbumCoverRequest.ProtoReflect.Descriptor instead.
-func (*StreamAlbumCoverRequest) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{4}
+func (x *UploadSongStreamMetadata) GetSongSize() int64 {
+	if x != nil {
+		return x.SongSize
+	}
+	return 0
 }
 
-func (x *StreamAlbumCoverRequest) GetAlbumCoverId() string {
+func (x *UploadSongStreamMetadata) GetSongSha256() string {
 	if x != nil {
-		return x.AlbumCoverId
+		return x.SongSha256
 	}
 	return ""
 }
 
-type StreamAlbumCoverResponse struct {
+type CreateUploadSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Metadata   *UploadSongStreamMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	AlbumCover []byte                    `protobuf:"bytes,2,opt,name=album_cover,json=albumCover,proto3" json:"album_cover,omitempty"`
 }
 
-func (x *StreamAlbumCoverResponse) Reset() {
-	*x = StreamAlbumCoverResponse{}
+func (x *CreateUploadSessionRequest) Reset() {
+	*x = CreateUploadSessionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[5]
+		mi := &file_songs_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StreamAlbumCoverResponse) String() string {
+func (x *CreateUploadSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamAlbumCoverResponse) ProtoMessage() {}
+func (*CreateUploadSessionRequest) ProtoMessage() {}
 
-func (x *StreamAlbumCoverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[5]
+func (x *CreateUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,50 +520,56 @@ func (x *StreamAlbumCoverResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamAlbumCoverResponse.ProtoReflect.Descriptor instead.
-func (*StreamAlbumCoverResponse) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CreateUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StreamAlbumCoverResponse) GetChunk() []byte {
+func (x *CreateUploadSessionRequest) GetMetadata() *UploadSongStreamMetadata {
 	if x != nil {
-		return x.Chunk
+		return x.Metadata
 	}
 	return nil
 }
 
-type SongMetadata struct {
+func (x *CreateUploadSessionRequest) GetAlbumCover() []byte {
+	if x != nil {
+		return x.AlbumCover
+	}
+	return nil
+}
+
+// Clients resume an interrupted upload by appending from committed_offset.
+type UploadSession struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	XId          string `protobuf:"bytes,1,opt,name=_id,json=Id,proto3" json:"_id,omitempty"`
-	Title        string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Artist       string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
-	Album        string `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
-	Description  string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	UploadedBy   string `protobuf:"bytes,6,opt,name=uploadedBy,proto3" json:"uploadedBy,omitempty"`
-	SongFileID   string `protobuf:"bytes,7,opt,name=songFileID,proto3" json:"songFileID,omitempty"`
-	AlbumCoverID string `protobuf:"bytes,8,opt,name=albumCoverID,proto3" json:"albumCoverID,omitempty"`
+	SessionId       string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	CommittedOffset int64  `protobuf:"varint,2,opt,name=committed_offset,json=committedOffset,proto3" json:"committed_offset,omitempty"`
+	SongSize        int64  `protobuf:"varint,3,opt,name=song_size,json=songSize,proto3" json:"song_size,omitempty"`
+	MaxChunkSize    int64  `protobuf:"varint,4,opt,name=max_chunk_size,json=maxChunkSize,proto3" json:"max_chunk_size,omitempty"`
+	// Unix seconds after which an idle session is discarded.
+	ExpiresAt int64 `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
 }
 
-func (x *SongMetadata) Reset() {
-	*x = SongMetadata{}
+func (x *UploadSession) Reset() {
+	*x = UploadSession{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[6]
+		mi := &file_songs_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SongMetadata) String() string {
+func (x *UploadSession) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SongMetadata) ProtoMessage() {}
+func (*UploadSession) ProtoMessage() {}
 
-func (x *SongMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[6]
+func (x *UploadSession) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,77 +580,105 @@ func (x *SongMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SongMetadata.ProtoReflect.Descriptor instead.
-func (*SongMetadata) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use UploadSession.ProtoReflect.Descriptor instead.
+func (*UploadSession) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SongMetadata) GetXId() string {
+func (x *UploadSession) GetSessionId() string {
 	if x != nil {
-		return x.XId
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *SongMetadata) GetTitle() string {
+func (x *UploadSession) GetCommittedOffset() int64 {
 	if x != nil {
-		return x.Title
+		return x.CommittedOffset
 	}
-	return ""
+	return 0
 }
 
-func (x *SongMetadata) GetArtist() string {
+func (x *UploadSession) GetSongSize() int64 {
 	if x != nil {
-		return x.Artist
+		return x.SongSize
 	}
-	return ""
+	return 0
 }
 
-func (x *SongMetadata) GetAlbum() string {
+func (x *UploadSession) GetMaxChunkSize() int64 {
 	if x != nil {
-		return x.Album
+		return x.MaxChunkSize
 	}
-	return ""
+	return 0
 }
 
-func (x *SongMetadata) GetDescription() string {
+func (x *UploadSession) GetExpiresAt() int64 {
 	if x != nil {
-		return x.Description
+		return x.ExpiresAt
 	}
-	return ""
+	return 0
 }
 
-func (x *SongMetadata) GetUploadedBy() string {
-	if x != nil {
-		return x.UploadedBy
+type GetUploadSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *GetUploadSessionRequest) Reset() {
+	*x = GetUploadSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *SongMetadata) GetSongFileID() string {
-	if x != nil {
-		return x.SongFileID
+func (x *GetUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadSessionRequest) ProtoMessage() {}
+
+func (x *GetUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SongMetadata) GetAlbumCoverID() string {
+// Deprecated: Use GetUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetUploadSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.AlbumCoverID
+		return x.SessionId
 	}
 	return ""
 }
 
-type GetUserSongsRequest struct {
+type AppendUploadChunkRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Offset    int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Chunk     []byte `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
 }
 
-func (x *GetUserSongsRequest) Reset() {
-	*x = GetUserSongsRequest{}
+func (x *AppendUploadChunkRequest) Reset() {
+	*x = AppendUploadChunkRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_songs_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -494,13 +686,13 @@ func (x *GetUserSongsRequest) Reset() {
 	}
 }
 
-func (x *GetUserSongsRequest) String() string {
+func (x *AppendUploadChunkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSongsRequest) ProtoMessage() {}
+func (*AppendUploadChunkRequest) ProtoMessage() {}
 
-func (x *GetUserSongsRequest) ProtoReflect() protoreflect.Message {
+func (x *AppendUploadChunkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_songs_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -512,28 +704,42 @@ func (x *GetUserSongsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSongsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserSongsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppendUploadChunkRequest.ProtoReflect.Descriptor instead.
+func (*AppendUploadChunkRequest) Descriptor() ([]byte, []int) {
 	return file_songs_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetUserSongsRequest) GetUserId() string {
+func (x *AppendUploadChunkRequest) GetSessionId() string {
 	if x != nil {
-		return x.UserId
+		return x.SessionId
 	}
 	return ""
 }
 
-type GetUserSongsResponse struct {
+func (x *AppendUploadChunkRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *AppendUploadChunkRequest) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type FinalizeUploadSessionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Songs []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *GetUserSongsResponse) Reset() {
-	*x = GetUserSongsResponse{}
+func (x *FinalizeUploadSessionRequest) Reset() {
+	*x = FinalizeUploadSessionRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_songs_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -541,13 +747,13 @@ func (x *GetUserSongsResponse) Reset() {
 	}
 }
 
-func (x *GetUserSongsResponse) String() string {
+func (x *FinalizeUploadSessionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSongsResponse) ProtoMessage() {}
+func (*FinalizeUploadSessionRequest) ProtoMessage() {}
 
-func (x *GetUserSongsResponse) ProtoReflect() protoreflect.Message {
+func (x *FinalizeUploadSessionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_songs_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -559,41 +765,1163 @@ func (x *GetUserSongsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSongsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserSongsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FinalizeUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeUploadSessionRequest) Descriptor() ([]byte, []int) {
 	return file_songs_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetUserSongsResponse) GetSongs() []*SongMetadata {
+func (x *FinalizeUploadSessionRequest) GetSessionId() string {
 	if x != nil {
-		return x.Songs
+		return x.SessionId
+	}
+	return ""
+}
+
+type StreamSongFileRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SongFileId string `protobuf:"bytes,1,opt,name=song_file_id,json=songFileId,proto3" json:"song_file_id,omitempty"`
+	// First byte to send.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Number of bytes to send; 0 streams to the end of the file.
+	Length int64 `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"`
+	// Bytes per response message; 0 uses the server default.
+	ChunkSize int32 `protobuf:"varint,4,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+}
+
+func (x *StreamSongFileRequest) Reset() {
+	*x = StreamSongFileRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSongFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSongFileRequest) ProtoMessage() {}
+
+func (x *StreamSongFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSongFileRequest.ProtoReflect.Descriptor instead.
+func (*StreamSongFileRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *StreamSongFileRequest) GetSongFileId() string {
+	if x != nil {
+		return x.SongFileId
+	}
+	return ""
+}
+
+func (x *StreamSongFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StreamSongFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+func (x *StreamSongFileRequest) GetChunkSize() int32 {
+	if x != nil {
+		return x.ChunkSize
+	}
+	return 0
+}
+
+type StreamSongFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// Offset of this chunk within the file.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Only set on the first message.
+	TotalSize   int64  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	ContentType string `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+}
+
+func (x *StreamSongFileResponse) Reset() {
+	*x = StreamSongFileResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamSongFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamSongFileResponse) ProtoMessage() {}
+
+func (x *StreamSongFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamSongFileResponse.ProtoReflect.Descriptor instead.
+func (*StreamSongFileResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamSongFileResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *StreamSongFileResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *StreamSongFileResponse) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+func (x *StreamSongFileResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+type StreamAlbumCoverRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AlbumCoverId string    `protobuf:"bytes,1,opt,name=album_cover_id,json=albumCoverId,proto3" json:"album_cover_id,omitempty"`
+	Size         CoverSize `protobuf:"varint,2,opt,name=size,proto3,enum=main.CoverSize" json:"size,omitempty"`
+}
+
+func (x *StreamAlbumCoverRequest) Reset() {
+	*x = StreamAlbumCoverRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAlbumCoverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAlbumCoverRequest) ProtoMessage() {}
+
+func (x *StreamAlbumCoverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAlbumCoverRequest.ProtoReflect.Descriptor instead.
+func (*StreamAlbumCoverRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StreamAlbumCoverRequest) GetAlbumCoverId() string {
+	if x != nil {
+		return x.AlbumCoverId
+	}
+	return ""
+}
+
+func (x *StreamAlbumCoverRequest) GetSize() CoverSize {
+	if x != nil {
+		return x.Size
+	}
+	return CoverSize_COVER_SIZE_ORIGINAL
+}
+
+type StreamAlbumCoverResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+}
+
+func (x *StreamAlbumCoverResponse) Reset() {
+	*x = StreamAlbumCoverResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamAlbumCoverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamAlbumCoverResponse) ProtoMessage() {}
+
+func (x *StreamAlbumCoverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamAlbumCoverResponse.ProtoReflect.Descriptor instead.
+func (*StreamAlbumCoverResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StreamAlbumCoverResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+type SongMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	XId                string `protobuf:"bytes,1,opt,name=_id,json=Id,proto3" json:"_id,omitempty"`
+	Title              string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist             string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album              string `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	Description        string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	UploadedBy         string `protobuf:"bytes,6,opt,name=uploadedBy,proto3" json:"uploadedBy,omitempty"`
+	SongFileID         string `protobuf:"bytes,7,opt,name=songFileID,proto3" json:"songFileID,omitempty"`
+	AlbumCoverID       string `protobuf:"bytes,8,opt,name=albumCoverID,proto3" json:"albumCoverID,omitempty"`
+	UploadedAt         int64  `protobuf:"varint,9,opt,name=uploadedAt,proto3" json:"uploadedAt,omitempty"`
+	SongFileSize       int64  `protobuf:"varint,10,opt,name=songFileSize,proto3" json:"songFileSize,omitempty"`
+	SongMimeType       string `protobuf:"bytes,11,opt,name=songMimeType,proto3" json:"songMimeType,omitempty"`
+	SongSha256         string `protobuf:"bytes,12,opt,name=songSha256,proto3" json:"songSha256,omitempty"`
+	AlbumCoverSize     int64  `protobuf:"varint,13,opt,name=albumCoverSize,proto3" json:"albumCoverSize,omitempty"`
+	AlbumCoverMimeType string `protobuf:"bytes,14,opt,name=albumCoverMimeType,proto3" json:"albumCoverMimeType,omitempty"`
+	AlbumCoverSha256   string `protobuf:"bytes,15,opt,name=albumCoverSha256,proto3" json:"albumCoverSha256,omitempty"`
+	TrackNumber        int32  `protobuf:"varint,16,opt,name=trackNumber,proto3" json:"trackNumber,omitempty"`
+	Year               int32  `protobuf:"varint,17,opt,name=year,proto3" json:"year,omitempty"`
+	Genre              string `protobuf:"bytes,18,opt,name=genre,proto3" json:"genre,omitempty"`
+	DurationMs         int64  `protobuf:"varint,19,opt,name=durationMs,proto3" json:"durationMs,omitempty"`
+	SampleRate         int32  `protobuf:"varint,20,opt,name=sampleRate,proto3" json:"sampleRate,omitempty"`
+	Channels           int32  `protobuf:"varint,21,opt,name=channels,proto3" json:"channels,omitempty"`
+	Bitrate            int32  `protobuf:"varint,22,opt,name=bitrate,proto3" json:"bitrate,omitempty"`
+	// Milliseconds since the epoch at which the song was moved to the trash;
+	// 0 if it is not trashed.
+	DeletedAt int64 `protobuf:"varint,23,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`
+	// Incremented by every metadata update; see UpdateSongMetadataRequest.version.
+	Version int64 `protobuf:"varint,24,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *SongMetadata) Reset() {
+	*x = SongMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SongMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SongMetadata) ProtoMessage() {}
+
+func (x *SongMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SongMetadata.ProtoReflect.Descriptor instead.
+func (*SongMetadata) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SongMetadata) GetXId() string {
+	if x != nil {
+		return x.XId
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetSongFileID() string {
+	if x != nil {
+		return x.SongFileID
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetAlbumCoverID() string {
+	if x != nil {
+		return x.AlbumCoverID
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetUploadedAt() int64 {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetSongFileSize() int64 {
+	if x != nil {
+		return x.SongFileSize
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetSongMimeType() string {
+	if x != nil {
+		return x.SongMimeType
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetSongSha256() string {
+	if x != nil {
+		return x.SongSha256
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetAlbumCoverSize() int64 {
+	if x != nil {
+		return x.AlbumCoverSize
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetAlbumCoverMimeType() string {
+	if x != nil {
+		return x.AlbumCoverMimeType
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetAlbumCoverSha256() string {
+	if x != nil {
+		return x.AlbumCoverSha256
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetTrackNumber() int32 {
+	if x != nil {
+		return x.TrackNumber
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetGenre() string {
+	if x != nil {
+		return x.Genre
+	}
+	return ""
+}
+
+func (x *SongMetadata) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetChannels() int32 {
+	if x != nil {
+		return x.Channels
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetBitrate() int32 {
+	if x != nil {
+		return x.Bitrate
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *SongMetadata) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SongListOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Defaults to 50, capped at 200.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque token from a previous response's next_page_token.
+	PageToken  string        `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	SortBy     SongSortField `protobuf:"varint,3,opt,name=sort_by,json=sortBy,proto3,enum=main.SongSortField" json:"sort_by,omitempty"`
+	Descending bool          `protobuf:"varint,4,opt,name=descending,proto3" json:"descending,omitempty"`
+	// Exact-match filters; empty means no filter.
+	Artist     string `protobuf:"bytes,5,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album      string `protobuf:"bytes,6,opt,name=album,proto3" json:"album,omitempty"`
+	UploadedBy string `protobuf:"bytes,7,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+}
+
+func (x *SongListOptions) Reset() {
+	*x = SongListOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SongListOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SongListOptions) ProtoMessage() {}
+
+func (x *SongListOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SongListOptions.ProtoReflect.Descriptor instead.
+func (*SongListOptions) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SongListOptions) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SongListOptions) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SongListOptions) GetSortBy() SongSortField {
+	if x != nil {
+		return x.SortBy
+	}
+	return SongSortField_SONG_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *SongListOptions) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *SongListOptions) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *SongListOptions) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *SongListOptions) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+type GetUserSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  string           `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Options *SongListOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *GetUserSongsRequest) Reset() {
+	*x = GetUserSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSongsRequest) ProtoMessage() {}
+
+func (x *GetUserSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSongsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSongsRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetUserSongsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetUserSongsRequest) GetOptions() *SongListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type GetUserSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs         []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int64           `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *GetUserSongsResponse) Reset() {
+	*x = GetUserSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUserSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSongsResponse) ProtoMessage() {}
+
+func (x *GetUserSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSongsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserSongsResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetUserSongsResponse) GetSongs() []*SongMetadata {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *GetUserSongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetUserSongsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetAllSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options *SongListOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *GetAllSongsRequest) Reset() {
+	*x = GetAllSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSongsRequest) ProtoMessage() {}
+
+func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSongsRequest.ProtoReflect.Descriptor instead.
+func (*GetAllSongsRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAllSongsRequest) GetOptions() *SongListOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type GetAllSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs         []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int64           `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+}
+
+func (x *GetAllSongsResponse) Reset() {
+	*x = GetAllSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllSongsResponse) ProtoMessage() {}
+
+func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllSongsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllSongsResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetAllSongsResponse) GetSongs() []*SongMetadata {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *GetAllSongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *GetAllSongsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type SearchSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Free text; the last word is also matched as a prefix for autocomplete.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Defaults to 20, capped at 100.
+	PageSize  int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SearchSongsRequest) Reset() {
+	*x = SearchSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsRequest) ProtoMessage() {}
+
+func (x *SearchSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSongsRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchSongsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSongsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchSongsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Songs are ordered by relevance, best match first.
+type SearchSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs         []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Counts the ranked songs only; see truncated.
+	TotalCount int64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Set when the query matched more songs than the server ranks (1000). The
+	// rest are not returned and total_count leaves them out.
+	Truncated bool `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"`
+}
+
+func (x *SearchSongsResponse) Reset() {
+	*x = SearchSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsResponse) ProtoMessage() {}
+
+func (x *SearchSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSongsResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchSongsResponse) GetSongs() []*SongMetadata {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+func (x *SearchSongsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchSongsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchSongsResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+type UpdateSongMetadataRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SongId      string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Album       string `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Fields to update, from title, artist, album and description. An absent
+	// or empty mask updates all four, so a field left unset is cleared.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// The version the client last read. If the song has changed since, the
+	// update is rejected with ABORTED. 0 skips the check.
+	Version int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpdateSongMetadataRequest) Reset() {
+	*x = UpdateSongMetadataRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSongMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongMetadataRequest) ProtoMessage() {}
+
+func (x *UpdateSongMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongMetadataRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSongMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateSongMetadataRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataRequest) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataRequest) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
 	}
 	return nil
 }
 
-type GetAllSongsRequest struct {
+func (x *UpdateSongMetadataRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateSongMetadataResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	// The version of the song after the update.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *UpdateSongMetadataResponse) Reset() {
+	*x = UpdateSongMetadataResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSongMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSongMetadataResponse) ProtoMessage() {}
+
+func (x *UpdateSongMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSongMetadataResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSongMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateSongMetadataResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UpdateSongMetadataResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateSongMetadataResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteSongRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Ignored: the files to delete are read from the stored song.
+	//
+	// Deprecated: Marked as deprecated in songs.proto.
+	SongFileId string `protobuf:"bytes,2,opt,name=song_file_id,json=songFileId,proto3" json:"song_file_id,omitempty"`
+	// Ignored: the files to delete are read from the stored song.
+	//
+	// Deprecated: Marked as deprecated in songs.proto.
+	AlbumCoverId string `protobuf:"bytes,3,opt,name=album_cover_id,json=albumCoverId,proto3" json:"album_cover_id,omitempty"`
 }
 
-func (x *GetAllSongsRequest) Reset() {
-	*x = GetAllSongsRequest{}
+func (x *DeleteSongRequest) Reset() {
+	*x = DeleteSongRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[9]
+		mi := &file_songs_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetAllSongsRequest) String() string {
+func (x *DeleteSongRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllSongsRequest) ProtoMessage() {}
+func (*DeleteSongRequest) ProtoMessage() {}
 
-func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[9]
+func (x *DeleteSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,36 +1932,60 @@ func (x *GetAllSongsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllSongsRequest.ProtoReflect.Descriptor instead.
-func (*GetAllSongsRequest) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use DeleteSongRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSongRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{23}
 }
 
-type GetAllSongsResponse struct {
+func (x *DeleteSongRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in songs.proto.
+func (x *DeleteSongRequest) GetSongFileId() string {
+	if x != nil {
+		return x.SongFileId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in songs.proto.
+func (x *DeleteSongRequest) GetAlbumCoverId() string {
+	if x != nil {
+		return x.AlbumCoverId
+	}
+	return ""
+}
+
+type DeleteSongResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Songs []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 }
 
-func (x *GetAllSongsResponse) Reset() {
-	*x = GetAllSongsResponse{}
+func (x *DeleteSongResponse) Reset() {
+	*x = DeleteSongResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[10]
+		mi := &file_songs_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetAllSongsResponse) String() string {
+func (x *DeleteSongResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllSongsResponse) ProtoMessage() {}
+func (*DeleteSongResponse) ProtoMessage() {}
 
-func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[10]
+func (x *DeleteSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,47 +1996,100 @@ func (x *GetAllSongsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllSongsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllSongsResponse) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use DeleteSongResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSongResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetAllSongsResponse) GetSongs() []*SongMetadata {
+func (x *DeleteSongResponse) GetMessage() string {
 	if x != nil {
-		return x.Songs
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeleteSongResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Lists the caller's trashed songs; options.uploaded_by is ignored.
+type ListTrashRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Options *SongListOptions `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTrashRequest) GetOptions() *SongListOptions {
+	if x != nil {
+		return x.Options
 	}
 	return nil
 }
 
-type UpdateSongMetadataRequest struct {
+type ListTrashResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SongId      string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
-	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Artist      string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
-	Album       string `protobuf:"bytes,4,opt,name=album,proto3" json:"album,omitempty"`
-	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Songs         []*SongMetadata `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int64           `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 }
 
-func (x *UpdateSongMetadataRequest) Reset() {
-	*x = UpdateSongMetadataRequest{}
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[11]
+		mi := &file_songs_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateSongMetadataRequest) String() string {
+func (x *ListTrashResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSongMetadataRequest) ProtoMessage() {}
+func (*ListTrashResponse) ProtoMessage() {}
 
-func (x *UpdateSongMetadataRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[11]
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -695,47 +2100,182 @@ func (x *UpdateSongMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSongMetadataRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSongMetadataRequest) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{26}
 }
 
-func (x *UpdateSongMetadataRequest) GetSongId() string {
+func (x *ListTrashResponse) GetSongs() []*SongMetadata {
 	if x != nil {
-		return x.SongId
+		return x.Songs
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateSongMetadataRequest) GetTitle() string {
+func (x *ListTrashResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Title
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *UpdateSongMetadataRequest) GetArtist() string {
+func (x *ListTrashResponse) GetTotalCount() int64 {
 	if x != nil {
-		return x.Artist
+		return x.TotalCount
+	}
+	return 0
+}
+
+type RestoreSongRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+}
+
+func (x *RestoreSongRequest) Reset() {
+	*x = RestoreSongRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSongRequest) ProtoMessage() {}
+
+func (x *RestoreSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSongRequest.ProtoReflect.Descriptor instead.
+func (*RestoreSongRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RestoreSongRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
 	}
 	return ""
 }
 
-func (x *UpdateSongMetadataRequest) GetAlbum() string {
+type RestoreSongResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+}
+
+func (x *RestoreSongResponse) Reset() {
+	*x = RestoreSongResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreSongResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreSongResponse) ProtoMessage() {}
+
+func (x *RestoreSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreSongResponse.ProtoReflect.Descriptor instead.
+func (*RestoreSongResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RestoreSongResponse) GetMessage() string {
 	if x != nil {
-		return x.Album
+		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdateSongMetadataRequest) GetDescription() string {
+func (x *RestoreSongResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Description
+		return x.Success
+	}
+	return false
+}
+
+type PurgeSongRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+}
+
+func (x *PurgeSongRequest) Reset() {
+	*x = PurgeSongRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_songs_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurgeSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeSongRequest) ProtoMessage() {}
+
+func (x *PurgeSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeSongRequest.ProtoReflect.Descriptor instead.
+func (*PurgeSongRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *PurgeSongRequest) GetSongId() string {
+	if x != nil {
+		return x.SongId
 	}
 	return ""
 }
 
-type UpdateSongMetadataResponse struct {
+type PurgeSongResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -744,23 +2284,23 @@ type UpdateSongMetadataResponse struct {
 	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
 }
 
-func (x *UpdateSongMetadataResponse) Reset() {
-	*x = UpdateSongMetadataResponse{}
+func (x *PurgeSongResponse) Reset() {
+	*x = PurgeSongResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[12]
+		mi := &file_songs_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateSongMetadataResponse) String() string {
+func (x *PurgeSongResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSongMetadataResponse) ProtoMessage() {}
+func (*PurgeSongResponse) ProtoMessage() {}
 
-func (x *UpdateSongMetadataResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[12]
+func (x *PurgeSongResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -771,52 +2311,53 @@ func (x *UpdateSongMetadataResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSongMetadataResponse.ProtoReflect.Descriptor instead.
-func (*UpdateSongMetadataResponse) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use PurgeSongResponse.ProtoReflect.Descriptor instead.
+func (*PurgeSongResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *UpdateSongMetadataResponse) GetMessage() string {
+func (x *PurgeSongResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-func (x *UpdateSongMetadataResponse) GetSuccess() bool {
+func (x *PurgeSongResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type DeleteSongRequest struct {
+type GetSongWaveformRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SongId       string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
-	SongFileId   string `protobuf:"bytes,2,opt,name=song_file_id,json=songFileId,proto3" json:"song_file_id,omitempty"`
-	AlbumCoverId string `protobuf:"bytes,3,opt,name=album_cover_id,json=albumCoverId,proto3" json:"album_cover_id,omitempty"`
+	SongId string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	// Number of buckets wanted. The smallest stored resolution that is at least
+	// this large is returned; 0 selects the largest.
+	Resolution int32 `protobuf:"varint,2,opt,name=resolution,proto3" json:"resolution,omitempty"`
 }
-
-func (x *DeleteSongRequest) Reset() {
-	*x = DeleteSongRequest{}
+
+func (x *GetSongWaveformRequest) Reset() {
+	*x = GetSongWaveformRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[13]
+		mi := &file_songs_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteSongRequest) String() string {
+func (x *GetSongWaveformRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSongRequest) ProtoMessage() {}
+func (*GetSongWaveformRequest) ProtoMessage() {}
 
-func (x *DeleteSongRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[13]
+func (x *GetSongWaveformRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,58 +2368,54 @@ func (x *DeleteSongRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSongRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSongRequest) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetSongWaveformRequest.ProtoReflect.Descriptor instead.
+func (*GetSongWaveformRequest) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *DeleteSongRequest) GetSongId() string {
+func (x *GetSongWaveformRequest) GetSongId() string {
 	if x != nil {
 		return x.SongId
 	}
 	return ""
 }
 
-func (x *DeleteSongRequest) GetSongFileId() string {
-	if x != nil {
-		return x.SongFileId
-	}
-	return ""
-}
-
-func (x *DeleteSongRequest) GetAlbumCoverId() string {
+func (x *GetSongWaveformRequest) GetResolution() int32 {
 	if x != nil {
-		return x.AlbumCoverId
+		return x.Resolution
 	}
-	return ""
+	return 0
 }
 
-type DeleteSongResponse struct {
+type GetSongWaveformResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Success bool   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	SongId  string `protobuf:"bytes,1,opt,name=song_id,json=songId,proto3" json:"song_id,omitempty"`
+	Buckets int32  `protobuf:"varint,2,opt,name=buckets,proto3" json:"buckets,omitempty"`
+	// A min and a max per bucket, interleaved, scaled to [-127, 127].
+	Peaks                []int32 `protobuf:"zigzag32,3,rep,packed,name=peaks,proto3" json:"peaks,omitempty"`
+	AvailableResolutions []int32 `protobuf:"varint,4,rep,packed,name=available_resolutions,json=availableResolutions,proto3" json:"available_resolutions,omitempty"`
 }
 
-func (x *DeleteSongResponse) Reset() {
-	*x = DeleteSongResponse{}
+func (x *GetSongWaveformResponse) Reset() {
+	*x = GetSongWaveformResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_songs_proto_msgTypes[14]
+		mi := &file_songs_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteSongResponse) String() string {
+func (x *GetSongWaveformResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSongResponse) ProtoMessage() {}
+func (*GetSongWaveformResponse) ProtoMessage() {}
 
-func (x *DeleteSongResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_songs_proto_msgTypes[14]
+func (x *GetSongWaveformResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_songs_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,92 +2426,258 @@ func (x *DeleteSongResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSongResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSongResponse) Descriptor() ([]byte, []int) {
-	return file_songs_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetSongWaveformResponse.ProtoReflect.Descriptor instead.
+func (*GetSongWaveformResponse) Descriptor() ([]byte, []int) {
+	return file_songs_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *DeleteSongResponse) GetMessage() string {
+func (x *GetSongWaveformResponse) GetSongId() string {
 	if x != nil {
-		return x.Message
+		return x.SongId
 	}
 	return ""
 }
 
-func (x *DeleteSongResponse) GetSuccess() bool {
+func (x *GetSongWaveformResponse) GetBuckets() int32 {
 	if x != nil {
-		return x.Success
+		return x.Buckets
 	}
-	return false
+	return 0
+}
+
+func (x *GetSongWaveformResponse) GetPeaks() []int32 {
+	if x != nil {
+		return x.Peaks
+	}
+	return nil
+}
+
+func (x *GetSongWaveformResponse) GetAvailableResolutions() []int32 {
+	if x != nil {
+		return x.AvailableResolutions
+	}
+	return nil
 }
 
 var File_songs_proto protoreflect.FileDescriptor
 
 var file_songs_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x6d,
-	0x61, 0x69, 0x6e, 0x22, 0xd8, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f,
-	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x20, 0x0a,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x42, 0x79,
-	0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a,
-	0x0b, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x22, 0x7a,
-	0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12,
-	0x19, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x39, 0x0a, 0x15, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46,
-	0x69, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x2e, 0x0a, 0x16, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53,
-	0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x3f, 0x0a, 0x17, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41,
-	0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x24, 0x0a, 0x0e, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43,
-	0x6f, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x30, 0x0a, 0x18, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0xe9, 0x01, 0x0a, 0x0c, 0x53, 0x6f, 0x6e,
-	0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x0f, 0x0a, 0x03, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75,
-	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x42, 0x79, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x42, 0x79,
-	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x44,
-	0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x44,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76,
-	0x65, 0x72, 0x49, 0x44, 0x22, 0x2e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53,
-	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
-	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x22, 0x40, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53,
+	0x61, 0x69, 0x6e, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdc, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62,
+	0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x23, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x64, 0x42, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x6f, 0x6e, 0x67, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x22, 0x7a, 0x0a, 0x12, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x17, 0x0a, 0x07,
+	0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0xb1, 0x01, 0x0a, 0x17, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3c, 0x0a, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x48, 0x00,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0a, 0x73, 0x6f,
+	0x6e, 0x67, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x09, 0x73, 0x6f, 0x6e, 0x67, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x2c, 0x0a, 0x11, 0x61,
+	0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x0f, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x22, 0xbe, 0x01, 0x0a, 0x18, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x6f, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x6e, 0x67, 0x5f,
+	0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x6f, 0x6e, 0x67,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x73, 0x68, 0x61,
+	0x32, 0x35, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x53,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x22, 0x79, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4d, 0x65, 0x74,
+	0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x6f, 0x6e, 0x67, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78,
+	0x5f, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x22, 0x38,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x67, 0x0a, 0x18, 0x41, 0x70, 0x70, 0x65,
+	0x6e, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x22, 0x3d, 0x0a, 0x1c, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0x88, 0x01, 0x0a, 0x15, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6f,
+	0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x0a,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x88, 0x01, 0x0a, 0x16,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x22, 0x64, 0x0a, 0x17, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x30, 0x0a, 0x18,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0xef,
+	0x05, 0x0a, 0x0c, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x0f, 0x0a, 0x03, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x49, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x6c, 0x62, 0x75, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x65, 0x64, 0x42, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x65, 0x64, 0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69,
+	0x6c, 0x65, 0x49, 0x44, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67,
+	0x46, 0x69, 0x6c, 0x65, 0x49, 0x44, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43,
+	0x6f, 0x76, 0x65, 0x72, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c,
+	0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x44, 0x12, 0x1e, 0x0a, 0x0a, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6f,
+	0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x6f, 0x6e, 0x67, 0x4d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x6f, 0x6e, 0x67, 0x4d, 0x69, 0x6d, 0x65, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x53, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x6c, 0x62, 0x75,
+	0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x61, 0x6c,
+	0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x4d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76,
+	0x65, 0x72, 0x4d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x6c,
+	0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x0f,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
+	0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x72, 0x61,
+	0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x79, 0x65, 0x61, 0x72,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x79, 0x65, 0x61, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x67, 0x65, 0x6e, 0x72, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x65, 0x6e,
+	0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0xea, 0x01, 0x0a, 0x0f, 0x53, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x2c, 0x0a, 0x07, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x62, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x6f, 0x72,
+	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x1f, 0x0a, 0x0b,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x64, 0x42, 0x79, 0x22, 0x5f, 0x0a,
+	0x13, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2f, 0x0a,
+	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x89,
+	0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f,
+	0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67,
+	0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x45, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73,
+	0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x88, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x6f, 0x6e,
+	0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x73, 0x6f,
+	0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x66, 0x0a, 0x12,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xa6, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53,
 	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05,
 	0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61,
 	0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
-	0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
-	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x3f, 0x0a, 0x13,
-	0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65,
-	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x22, 0x9a, 0x01,
+	0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0xf1, 0x01,
 	0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61,
 	0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73,
 	0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
@@ -984,59 +2687,170 @@ var file_songs_proto_rawDesc = []byte{
 	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
 	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x50, 0x0a, 0x1a, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x74, 0x0a, 0x11,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x0a, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x6a, 0x0a, 0x1a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7c, 0x0a,
+	0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0c, 0x73,
+	0x6f, 0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x0e, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x61,
+	0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x48, 0x0a, 0x12, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x43, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61,
+	0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6d, 0x61, 0x69,
+	0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x28, 0x0a, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x52, 0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65,
+	0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x2d, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x6e,
+	0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67,
+	0x49, 0x64, 0x22, 0x49, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6e,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x2b, 0x0a,
+	0x10, 0x50, 0x75, 0x72, 0x67, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x73, 0x6f,
-	0x6e, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0a, 0x73, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e,
-	0x61, 0x6c, 0x62, 0x75, 0x6d, 0x5f, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
-	0x49, 0x64, 0x22, 0x48, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0x97, 0x04, 0x0a,
-	0x0b, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0a,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x17, 0x2e, 0x6d, 0x61, 0x69,
-	0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a,
-	0x0e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12,
-	0x1b, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e,
-	0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d,
-	0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x10,
-	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72,
-	0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c,
-	0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62,
-	0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
-	0x01, 0x12, 0x45, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67,
-	0x73, 0x12, 0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72,
-	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d,
-	0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41,
-	0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47,
-	0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53,
-	0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x12,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0x12, 0x1f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
-	0x6f, 0x6e, 0x67, 0x12, 0x17, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74,
-	0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d,
-	0x61, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x03, 0x5a, 0x01, 0x2e, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x11, 0x50, 0x75,
+	0x72, 0x67, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x22, 0x51, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x57, 0x61,
+	0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x97, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x53, 0x6f,
+	0x6e, 0x67, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x6f, 0x6e, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x6e, 0x67, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x62, 0x75,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x61, 0x6b, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x11, 0x52, 0x05, 0x70, 0x65, 0x61, 0x6b, 0x73, 0x12, 0x33, 0x0a, 0x15, 0x61,
+	0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x05, 0x52, 0x14, 0x61, 0x76, 0x61, 0x69,
+	0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2a, 0x67, 0x0a, 0x09, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x17, 0x0a,
+	0x13, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x4f, 0x52, 0x49, 0x47,
+	0x49, 0x4e, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x5f,
+	0x53, 0x49, 0x5a, 0x45, 0x5f, 0x53, 0x4d, 0x41, 0x4c, 0x4c, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11,
+	0x43, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x49, 0x5a, 0x45, 0x5f, 0x4d, 0x45, 0x44, 0x49, 0x55,
+	0x4d, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10, 0x43, 0x4f, 0x56, 0x45, 0x52, 0x5f, 0x53, 0x49, 0x5a,
+	0x45, 0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x03, 0x2a, 0x88, 0x01, 0x0a, 0x0d, 0x53, 0x6f,
+	0x6e, 0x67, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x1b, 0x53,
+	0x4f, 0x4e, 0x47, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15,
+	0x53, 0x4f, 0x4e, 0x47, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f,
+	0x54, 0x49, 0x54, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x4e, 0x47, 0x5f,
+	0x53, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x41, 0x52, 0x54, 0x49, 0x53,
+	0x54, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x4f, 0x4e, 0x47, 0x5f, 0x53, 0x4f, 0x52, 0x54,
+	0x5f, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f, 0x55, 0x50, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x44, 0x5f,
+	0x41, 0x54, 0x10, 0x03, 0x32, 0xf1, 0x09, 0x0a, 0x0b, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f,
+	0x6e, 0x67, 0x12, 0x17, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x10, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x6f, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x12, 0x4c, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x2e, 0x6d, 0x61,
+	0x69, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65,
+	0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x48, 0x0a, 0x11, 0x41, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
+	0x1e, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x13, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x55, 0x0a, 0x15, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x6f, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6d, 0x61, 0x69,
+	0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53, 0x6f, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x10, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x12, 0x1d,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62, 0x75,
+	0x6d, 0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x41, 0x6c, 0x62, 0x75, 0x6d,
+	0x43, 0x6f, 0x76, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x4e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f,
+	0x72, 0x6d, 0x12, 0x1c, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e,
+	0x67, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x57,
+	0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x45, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12,
+	0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f,
+	0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6d, 0x61, 0x69,
+	0x6e, 0x2e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c,
+	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x53, 0x6f, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x12, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57,
+	0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x17, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x6f, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74,
+	0x54, 0x72, 0x61, 0x73, 0x68, 0x12, 0x16, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x54, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x72, 0x61, 0x73, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x18, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x6f,
+	0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x50, 0x75,
+	0x72, 0x67, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x50,
+	0x75, 0x72, 0x67, 0x65, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x17, 0x2e, 0x6d, 0x61, 0x69, 0x6e, 0x2e, 0x50, 0x75, 0x72, 0x67, 0x65, 0x53, 0x6f, 0x6e, 0x67,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x03, 0x5a, 0x01, 0x2e, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1051,46 +2865,98 @@ func file_songs_proto_rawDescGZIP() []byte {
 	return file_songs_proto_rawDescData
 }
 
-var file_songs_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_songs_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_songs_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_songs_proto_goTypes = []any{
-	(*UploadSongRequest)(nil),          // 0: main.UploadSongRequest
-	(*UploadSongResponse)(nil),         // 1: main.UploadSongResponse
-	(*StreamSongFileRequest)(nil),      // 2: main.StreamSongFileRequest
-	(*StreamSongFileResponse)(nil),     // 3: main.StreamSongFileResponse
-	(*StreamAlbumCoverRequest)(nil),    // 4: main.StreamAlbumCoverRequest
-	(*StreamAlbumCoverResponse)(nil),   // 5: main.StreamAlbumCoverResponse
-	(*SongMetadata)(nil),               // 6: main.SongMetadata
-	(*GetUserSongsRequest)(nil),        // 7: main.GetUserSongsRequest
-	(*GetUserSongsResponse)(nil),       // 8: main.GetUserSongsResponse
-	(*GetAllSongsRequest)(nil),         // 9: main.GetAllSongsRequest
-	(*GetAllSongsResponse)(nil),        // 10: main.GetAllSongsResponse
-	(*UpdateSongMetadataRequest)(nil),  // 11: main.UpdateSongMetadataRequest
-	(*UpdateSongMetadataResponse)(nil), // 12: main.UpdateSongMetadataResponse
-	(*DeleteSongRequest)(nil),          // 13: main.DeleteSongRequest
-	(*DeleteSongResponse)(nil),         // 14: main.DeleteSongResponse
+	(CoverSize)(0),                       // 0: main.CoverSize
+	(SongSortField)(0),                   // 1: main.SongSortField
+	(*UploadSongRequest)(nil),            // 2: main.UploadSongRequest
+	(*UploadSongResponse)(nil),           // 3: main.UploadSongResponse
+	(*UploadSongStreamRequest)(nil),      // 4: main.UploadSongStreamRequest
+	(*UploadSongStreamMetadata)(nil),     // 5: main.UploadSongStreamMetadata
+	(*CreateUploadSessionRequest)(nil),   // 6: main.CreateUploadSessionRequest
+	(*UploadSession)(nil),                // 7: main.UploadSession
+	(*GetUploadSessionRequest)(nil),      // 8: main.GetUploadSessionRequest
+	(*AppendUploadChunkRequest)(nil),     // 9: main.AppendUploadChunkRequest
+	(*FinalizeUploadSessionRequest)(nil), // 10: main.FinalizeUploadSessionRequest
+	(*StreamSongFileRequest)(nil),        // 11: main.StreamSongFileRequest
+	(*StreamSongFileResponse)(nil),       // 12: main.StreamSongFileResponse
+	(*StreamAlbumCoverRequest)(nil),      // 13: main.StreamAlbumCoverRequest
+	(*StreamAlbumCoverResponse)(nil),     // 14: main.StreamAlbumCoverResponse
+	(*SongMetadata)(nil),                 // 15: main.SongMetadata
+	(*SongListOptions)(nil),              // 16: main.SongListOptions
+	(*GetUserSongsRequest)(nil),          // 17: main.GetUserSongsRequest
+	(*GetUserSongsResponse)(nil),         // 18: main.GetUserSongsResponse
+	(*GetAllSongsRequest)(nil),           // 19: main.GetAllSongsRequest
+	(*GetAllSongsResponse)(nil),          // 20: main.GetAllSongsResponse
+	(*SearchSongsRequest)(nil),           // 21: main.SearchSongsRequest
+	(*SearchSongsResponse)(nil),          // 22: main.SearchSongsResponse
+	(*UpdateSongMetadataRequest)(nil),    // 23: main.UpdateSongMetadataRequest
+	(*UpdateSongMetadataResponse)(nil),   // 24: main.UpdateSongMetadataResponse
+	(*DeleteSongRequest)(nil),            // 25: main.DeleteSongRequest
+	(*DeleteSongResponse)(nil),           // 26: main.DeleteSongResponse
+	(*ListTrashRequest)(nil),             // 27: main.ListTrashRequest
+	(*ListTrashResponse)(nil),            // 28: main.ListTrashResponse
+	(*RestoreSongRequest)(nil),           // 29: main.RestoreSongRequest
+	(*RestoreSongResponse)(nil),          // 30: main.RestoreSongResponse
+	(*PurgeSongRequest)(nil),             // 31: main.PurgeSongRequest
+	(*PurgeSongResponse)(nil),            // 32: main.PurgeSongResponse
+	(*GetSongWaveformRequest)(nil),       // 33: main.GetSongWaveformRequest
+	(*GetSongWaveformResponse)(nil),      // 34: main.GetSongWaveformResponse
+	(*fieldmaskpb.FieldMask)(nil),        // 35: google.protobuf.FieldMask
 }
 var file_songs_proto_depIdxs = []int32{
-	6,  // 0: main.GetUserSongsResponse.songs:type_name -> main.SongMetadata
-	6,  // 1: main.GetAllSongsResponse.songs:type_name -> main.SongMetadata
-	0,  // 2: main.SongService.UploadSong:input_type -> main.UploadSongRequest
-	2,  // 3: main.SongService.StreamSongFile:input_type -> main.StreamSongFileRequest
-	4,  // 4: main.SongService.StreamAlbumCover:input_type -> main.StreamAlbumCoverRequest
-	7,  // 5: main.SongService.GetUserSongs:input_type -> main.GetUserSongsRequest
-	9,  // 6: main.SongService.GetAllSongs:input_type -> main.GetAllSongsRequest
-	11, // 7: main.SongService.UpdateSongMetadata:input_type -> main.UpdateSongMetadataRequest
-	13, // 8: main.SongService.DeleteSong:input_type -> main.DeleteSongRequest
-	1,  // 9: main.SongService.UploadSong:output_type -> main.UploadSongResponse
-	3,  // 10: main.SongService.StreamSongFile:output_type -> main.StreamSongFileResponse
-	5,  // 11: main.SongService.StreamAlbumCover:output_type -> main.StreamAlbumCoverResponse
-	8,  // 12: main.SongService.GetUserSongs:output_type -> main.GetUserSongsResponse
-	10, // 13: main.SongService.GetAllSongs:output_type -> main.GetAllSongsResponse
-	12, // 14: main.SongService.UpdateSongMetadata:output_type -> main.UpdateSongMetadataResponse
-	14, // 15: main.SongService.DeleteSong:output_type -> main.DeleteSongResponse
-	9,  // [9:16] is the sub-list for method output_type
-	2,  // [2:9] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	5,  // 0: main.UploadSongStreamRequest.metadata:type_name -> main.UploadSongStreamMetadata
+	5,  // 1: main.CreateUploadSessionRequest.metadata:type_name -> main.UploadSongStreamMetadata
+	0,  // 2: main.StreamAlbumCoverRequest.size:type_name -> main.CoverSize
+	1,  // 3: main.SongListOptions.sort_by:type_name -> main.SongSortField
+	16, // 4: main.GetUserSongsRequest.options:type_name -> main.SongListOptions
+	15, // 5: main.GetUserSongsResponse.songs:type_name -> main.SongMetadata
+	16, // 6: main.GetAllSongsRequest.options:type_name -> main.SongListOptions
+	15, // 7: main.GetAllSongsResponse.songs:type_name -> main.SongMetadata
+	15, // 8: main.SearchSongsResponse.songs:type_name -> main.SongMetadata
+	35, // 9: main.UpdateSongMetadataRequest.update_mask:type_name -> google.protobuf.FieldMask
+	16, // 10: main.ListTrashRequest.options:type_name -> main.SongListOptions
+	15, // 11: main.ListTrashResponse.songs:type_name -> main.SongMetadata
+	2,  // 12: main.SongService.UploadSong:input_type -> main.UploadSongRequest
+	4,  // 13: main.SongService.UploadSongStream:input_type -> main.UploadSongStreamRequest
+	6,  // 14: main.SongService.CreateUploadSession:input_type -> main.CreateUploadSessionRequest
+	8,  // 15: main.SongService.GetUploadSession:input_type -> main.GetUploadSessionRequest
+	9,  // 16: main.SongService.AppendUploadChunk:input_type -> main.AppendUploadChunkRequest
+	10, // 17: main.SongService.FinalizeUploadSession:input_type -> main.FinalizeUploadSessionRequest
+	11, // 18: main.SongService.StreamSongFile:input_type -> main.StreamSongFileRequest
+	13, // 19: main.SongService.StreamAlbumCover:input_type -> main.StreamAlbumCoverRequest
+	33, // 20: main.SongService.GetSongWaveform:input_type -> main.GetSongWaveformRequest
+	17, // 21: main.SongService.GetUserSongs:input_type -> main.GetUserSongsRequest
+	19, // 22: main.SongService.GetAllSongs:input_type -> main.GetAllSongsRequest
+	21, // 23: main.SongService.SearchSongs:input_type -> main.SearchSongsRequest
+	23, // 24: main.SongService.UpdateSongMetadata:input_type -> main.UpdateSongMetadataRequest
+	25, // 25: main.SongService.DeleteSong:input_type -> main.DeleteSongRequest
+	27, // 26: main.SongService.ListTrash:input_type -> main.ListTrashRequest
+	29, // 27: main.SongService.RestoreSong:input_type -> main.RestoreSongRequest
+	31, // 28: main.SongService.PurgeSong:input_type -> main.PurgeSongRequest
+	3,  // 29: main.SongService.UploadSong:output_type -> main.UploadSongResponse
+	3,  // 30: main.SongService.UploadSongStream:output_type -> main.UploadSongResponse
+	7,  // 31: main.SongService.CreateUploadSession:output_type -> main.UploadSession
+	7,  // 32: main.SongService.GetUploadSession:output_type -> main.UploadSession
+	7,  // 33: main.SongService.AppendUploadChunk:output_type -> main.UploadSession
+	3,  // 34: main.SongService.FinalizeUploadSession:output_type -> main.UploadSongResponse
+	12, // 35: main.SongService.StreamSongFile:output_type -> main.StreamSongFileResponse
+	14, // 36: main.SongService.StreamAlbumCover:output_type -> main.StreamAlbumCoverResponse
+	34, // 37: main.SongService.GetSongWaveform:output_type -> main.GetSongWaveformResponse
+	18, // 38: main.SongService.GetUserSongs:output_type -> main.GetUserSongsResponse
+	20, // 39: main.SongService.GetAllSongs:output_type -> main.GetAllSongsResponse
+	22, // 40: main.SongService.SearchSongs:output_type -> main.SearchSongsResponse
+	24, // 41: main.SongService.UpdateSongMetadata:output_type -> main.UpdateSongMetadataResponse
+	26, // 42: main.SongService.DeleteSong:output_type -> main.DeleteSongResponse
+	28, // 43: main.SongService.ListTrash:output_type -> main.ListTrashResponse
+	30, // 44: main.SongService.RestoreSong:output_type -> main.RestoreSongResponse
+	32, // 45: main.SongService.PurgeSong:output_type -> main.PurgeSongResponse
+	29, // [29:46] is the sub-list for method output_type
+	12, // [12:29] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_songs_proto_init() }
@@ -1124,7 +2990,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*StreamSongFileRequest); i {
+			switch v := v.(*UploadSongStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1136,7 +3002,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*StreamSongFileResponse); i {
+			switch v := v.(*UploadSongStreamMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1148,7 +3014,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*StreamAlbumCoverRequest); i {
+			switch v := v.(*CreateUploadSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1160,7 +3026,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*StreamAlbumCoverResponse); i {
+			switch v := v.(*UploadSession); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1172,7 +3038,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*SongMetadata); i {
+			switch v := v.(*GetUploadSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1184,7 +3050,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*GetUserSongsRequest); i {
+			switch v := v.(*AppendUploadChunkRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1196,7 +3062,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*GetUserSongsResponse); i {
+			switch v := v.(*FinalizeUploadSessionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1208,7 +3074,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*GetAllSongsRequest); i {
+			switch v := v.(*StreamSongFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1220,7 +3086,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*GetAllSongsResponse); i {
+			switch v := v.(*StreamSongFileResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1232,7 +3098,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[11].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateSongMetadataRequest); i {
+			switch v := v.(*StreamAlbumCoverRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1244,7 +3110,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*UpdateSongMetadataResponse); i {
+			switch v := v.(*StreamAlbumCoverResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1256,7 +3122,7 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*DeleteSongRequest); i {
+			switch v := v.(*SongMetadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1268,6 +3134,126 @@ func file_songs_proto_init() {
 			}
 		}
 		file_songs_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*SongListOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUserSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUserSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAllSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAllSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSongMetadataRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSongMetadataResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteSongRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*DeleteSongResponse); i {
 			case 0:
 				return &v.state
@@ -1279,19 +3265,121 @@ func file_songs_proto_init() {
 				return nil
 			}
 		}
+		file_songs_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTrashRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*ListTrashResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*RestoreSongRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*RestoreSongResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*PurgeSongRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*PurgeSongResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSongWaveformRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_songs_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSongWaveformResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_songs_proto_msgTypes[2].OneofWrappers = []any{
+		(*UploadSongStreamRequest_Metadata)(nil),
+		(*UploadSongStreamRequest_SongChunk)(nil),
+		(*UploadSongStreamRequest_AlbumCoverChunk)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_songs_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   15,
+			NumEnums:      2,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_songs_proto_goTypes,
 		DependencyIndexes: file_songs_proto_depIdxs,
+		EnumInfos:         file_songs_proto_enumTypes,
 		MessageInfos:      file_songs_proto_msgTypes,
 	}.Build()
 	File_songs_proto = out.File
@@ -19,13 +19,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SongService_UploadSong_FullMethodName         = "/main.SongService/UploadSong"
-	SongService_StreamSongFile_FullMethodName     = "/main.SongService/StreamSongFile"
-	SongService_StreamAlbumCover_FullMethodName   = "/main.SongService/StreamAlbumCover"
-	SongService_GetUserSongs_FullMethodName       = "/main.SongService/GetUserSongs"
-	SongService_GetAllSongs_FullMethodName        = "/main.SongService/GetAllSongs"
-	SongService_UpdateSongMetadata_FullMethodName = "/main.SongService/UpdateSongMetadata"
-	SongService_DeleteSong_FullMethodName         = "/main.SongService/DeleteSong"
+	SongService_UploadSong_FullMethodName            = "/main.SongService/UploadSong"
+	SongService_UploadSongStream_FullMethodName      = "/main.SongService/UploadSongStream"
+	SongService_CreateUploadSession_FullMethodName   = "/main.SongService/CreateUploadSession"
+	SongService_GetUploadSession_FullMethodName      = "/main.SongService/GetUploadSession"
+	SongService_AppendUploadChunk_FullMethodName     = "/main.SongService/AppendUploadChunk"
+	SongService_FinalizeUploadSession_FullMethodName = "/main.SongService/FinalizeUploadSession"
+	SongService_StreamSongFile_FullMethodName        = "/main.SongService/StreamSongFile"
+	SongService_StreamAlbumCover_FullMethodName      = "/main.SongService/StreamAlbumCover"
+	SongService_GetSongWaveform_FullMethodName       = "/main.SongService/GetSongWaveform"
+	SongService_GetUserSongs_FullMethodName          = "/main.SongService/GetUserSongs"
+	SongService_GetAllSongs_FullMethodName           = "/main.SongService/GetAllSongs"
+	SongService_SearchSongs_FullMethodName           = "/main.SongService/SearchSongs"
+	SongService_UpdateSongMetadata_FullMethodName    = "/main.SongService/UpdateSongMetadata"
+	SongService_DeleteSong_FullMethodName            = "/main.SongService/DeleteSong"
+	SongService_ListTrash_FullMethodName             = "/main.SongService/ListTrash"
+	SongService_RestoreSong_FullMethodName           = "/main.SongService/RestoreSong"
+	SongService_PurgeSong_FullMethodName             = "/main.SongService/PurgeSong"
 )
 
 // SongServiceClient is the client API for SongService service.
@@ -33,12 +43,25 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SongServiceClient interface {
 	UploadSong(ctx context.Context, in *UploadSongRequest, opts ...grpc.CallOption) (*UploadSongResponse, error)
+	UploadSongStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadSongStreamRequest, UploadSongResponse], error)
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error)
+	GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error)
+	AppendUploadChunk(ctx context.Context, in *AppendUploadChunkRequest, opts ...grpc.CallOption) (*UploadSession, error)
+	FinalizeUploadSession(ctx context.Context, in *FinalizeUploadSessionRequest, opts ...grpc.CallOption) (*UploadSongResponse, error)
 	StreamSongFile(ctx context.Context, in *StreamSongFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamSongFileResponse], error)
 	StreamAlbumCover(ctx context.Context, in *StreamAlbumCoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAlbumCoverResponse], error)
+	GetSongWaveform(ctx context.Context, in *GetSongWaveformRequest, opts ...grpc.CallOption) (*GetSongWaveformResponse, error)
 	GetUserSongs(ctx context.Context, in *GetUserSongsRequest, opts ...grpc.CallOption) (*GetUserSongsResponse, error)
 	GetAllSongs(ctx context.Context, in *GetAllSongsRequest, opts ...grpc.CallOption) (*GetAllSongsResponse, error)
+	SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error)
 	UpdateSongMetadata(ctx context.Context, in *UpdateSongMetadataRequest, opts ...grpc.CallOption) (*UpdateSongMetadataResponse, error)
+	// Moves a song to the trash. Trashed songs are hidden from listings and
+	// search, and purged after the retention period.
 	DeleteSong(ctx context.Context, in *DeleteSongRequest, opts ...grpc.CallOption) (*DeleteSongResponse, error)
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error)
+	RestoreSong(ctx context.Context, in *RestoreSongRequest, opts ...grpc.CallOption) (*RestoreSongResponse, error)
+	// Permanently deletes a trashed song and its files.
+	PurgeSong(ctx context.Context, in *PurgeSongRequest, opts ...grpc.CallOption) (*PurgeSongResponse, error)
 }
 
 type songServiceClient struct {
@@ -59,9 +82,62 @@ func (c *songServiceClient) UploadSong(ctx context.Context, in *UploadSongReques
 	return out, nil
 }
 
+func (c *songServiceClient) UploadSongStream(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadSongStreamRequest, UploadSongResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SongService_ServiceDesc.Streams[0], SongService_UploadSongStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadSongStreamRequest, UploadSongResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongService_UploadSongStreamClient = grpc.ClientStreamingClient[UploadSongStreamRequest, UploadSongResponse]
+
+func (c *songServiceClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, SongService_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songServiceClient) GetUploadSession(ctx context.Context, in *GetUploadSessionRequest, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, SongService_GetUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songServiceClient) AppendUploadChunk(ctx context.Context, in *AppendUploadChunkRequest, opts ...grpc.CallOption) (*UploadSession, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSession)
+	err := c.cc.Invoke(ctx, SongService_AppendUploadChunk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songServiceClient) FinalizeUploadSession(ctx context.Context, in *FinalizeUploadSessionRequest, opts ...grpc.CallOption) (*UploadSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadSongResponse)
+	err := c.cc.Invoke(ctx, SongService_FinalizeUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *songServiceClient) StreamSongFile(ctx context.Context, in *StreamSongFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamSongFileResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SongService_ServiceDesc.Streams[0], SongService_StreamSongFile_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SongService_ServiceDesc.Streams[1], SongService_StreamSongFile_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +156,7 @@ type SongService_StreamSongFileClient = grpc.ServerStreamingClient[StreamSongFil
 
 func (c *songServiceClient) StreamAlbumCover(ctx context.Context, in *StreamAlbumCoverRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamAlbumCoverResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &SongService_ServiceDesc.Streams[1], SongService_StreamAlbumCover_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &SongService_ServiceDesc.Streams[2], SongService_StreamAlbumCover_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -97,6 +173,16 @@ func (c *songServiceClient) StreamAlbumCover(ctx context.Context, in *StreamAlbu
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SongService_StreamAlbumCoverClient = grpc.ServerStreamingClient[StreamAlbumCoverResponse]
 
+func (c *songServiceClient) GetSongWaveform(ctx context.Context, in *GetSongWaveformRequest, opts ...grpc.CallOption) (*GetSongWaveformResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSongWaveformResponse)
+	err := c.cc.Invoke(ctx, SongService_GetSongWaveform_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *songServiceClient) GetUserSongs(ctx context.Context, in *GetUserSongsRequest, opts ...grpc.CallOption) (*GetUserSongsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetUserSongsResponse)
@@ -117,6 +203,16 @@ func (c *songServiceClient) GetAllSongs(ctx context.Context, in *GetAllSongsRequ
 	return out, nil
 }
 
+func (c *songServiceClient) SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSongsResponse)
+	err := c.cc.Invoke(ctx, SongService_SearchSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *songServiceClient) UpdateSongMetadata(ctx context.Context, in *UpdateSongMetadataRequest, opts ...grpc.CallOption) (*UpdateSongMetadataResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(UpdateSongMetadataResponse)
@@ -137,17 +233,60 @@ func (c *songServiceClient) DeleteSong(ctx context.Context, in *DeleteSongReques
 	return out, nil
 }
 
+func (c *songServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListTrashResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTrashResponse)
+	err := c.cc.Invoke(ctx, SongService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songServiceClient) RestoreSong(ctx context.Context, in *RestoreSongRequest, opts ...grpc.CallOption) (*RestoreSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreSongResponse)
+	err := c.cc.Invoke(ctx, SongService_RestoreSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *songServiceClient) PurgeSong(ctx context.Context, in *PurgeSongRequest, opts ...grpc.CallOption) (*PurgeSongResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeSongResponse)
+	err := c.cc.Invoke(ctx, SongService_PurgeSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SongServiceServer is the server API for SongService service.
 // All implementations must embed UnimplementedSongServiceServer
 // for forward compatibility.
 type SongServiceServer interface {
 	UploadSong(context.Context, *UploadSongRequest) (*UploadSongResponse, error)
+	UploadSongStream(grpc.ClientStreamingServer[UploadSongStreamRequest, UploadSongResponse]) error
+	CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*UploadSession, error)
+	GetUploadSession(context.Context, *GetUploadSessionRequest) (*UploadSession, error)
+	AppendUploadChunk(context.Context, *AppendUploadChunkRequest) (*UploadSession, error)
+	FinalizeUploadSession(context.Context, *FinalizeUploadSessionRequest) (*UploadSongResponse, error)
 	StreamSongFile(*StreamSongFileRequest, grpc.ServerStreamingServer[StreamSongFileResponse]) error
 	StreamAlbumCover(*StreamAlbumCoverRequest, grpc.ServerStreamingServer[StreamAlbumCoverResponse]) error
+	GetSongWaveform(context.Context, *GetSongWaveformRequest) (*GetSongWaveformResponse, error)
 	GetUserSongs(context.Context, *GetUserSongsRequest) (*GetUserSongsResponse, error)
 	GetAllSongs(context.Context, *GetAllSongsRequest) (*GetAllSongsResponse, error)
+	SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error)
 	UpdateSongMetadata(context.Context, *UpdateSongMetadataRequest) (*UpdateSongMetadataResponse, error)
+	// Moves a song to the trash. Trashed songs are hidden from listings and
+	// search, and purged after the retention period.
 	DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error)
+	ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error)
+	RestoreSong(context.Context, *RestoreSongRequest) (*RestoreSongResponse, error)
+	// Permanently deletes a trashed song and its files.
+	PurgeSong(context.Context, *PurgeSongRequest) (*PurgeSongResponse, error)
 	mustEmbedUnimplementedSongServiceServer()
 }
 
@@ -161,24 +300,54 @@ type UnimplementedSongServiceServer struct{}
 func (UnimplementedSongServiceServer) UploadSong(context.Context, *UploadSongRequest) (*UploadSongResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UploadSong not implemented")
 }
+func (UnimplementedSongServiceServer) UploadSongStream(grpc.ClientStreamingServer[UploadSongStreamRequest, UploadSongResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadSongStream not implemented")
+}
+func (UnimplementedSongServiceServer) CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedSongServiceServer) GetUploadSession(context.Context, *GetUploadSessionRequest) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUploadSession not implemented")
+}
+func (UnimplementedSongServiceServer) AppendUploadChunk(context.Context, *AppendUploadChunkRequest) (*UploadSession, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendUploadChunk not implemented")
+}
+func (UnimplementedSongServiceServer) FinalizeUploadSession(context.Context, *FinalizeUploadSessionRequest) (*UploadSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinalizeUploadSession not implemented")
+}
 func (UnimplementedSongServiceServer) StreamSongFile(*StreamSongFileRequest, grpc.ServerStreamingServer[StreamSongFileResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamSongFile not implemented")
 }
 func (UnimplementedSongServiceServer) StreamAlbumCover(*StreamAlbumCoverRequest, grpc.ServerStreamingServer[StreamAlbumCoverResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamAlbumCover not implemented")
 }
+func (UnimplementedSongServiceServer) GetSongWaveform(context.Context, *GetSongWaveformRequest) (*GetSongWaveformResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSongWaveform not implemented")
+}
 func (UnimplementedSongServiceServer) GetUserSongs(context.Context, *GetUserSongsRequest) (*GetUserSongsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserSongs not implemented")
 }
 func (UnimplementedSongServiceServer) GetAllSongs(context.Context, *GetAllSongsRequest) (*GetAllSongsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllSongs not implemented")
 }
+func (UnimplementedSongServiceServer) SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSongs not implemented")
+}
 func (UnimplementedSongServiceServer) UpdateSongMetadata(context.Context, *UpdateSongMetadataRequest) (*UpdateSongMetadataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateSongMetadata not implemented")
 }
 func (UnimplementedSongServiceServer) DeleteSong(context.Context, *DeleteSongRequest) (*DeleteSongResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSong not implemented")
 }
+func (UnimplementedSongServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListTrashResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedSongServiceServer) RestoreSong(context.Context, *RestoreSongRequest) (*RestoreSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSong not implemented")
+}
+func (UnimplementedSongServiceServer) PurgeSong(context.Context, *PurgeSongRequest) (*PurgeSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeSong not implemented")
+}
 func (UnimplementedSongServiceServer) mustEmbedUnimplementedSongServiceServer() {}
 func (UnimplementedSongServiceServer) testEmbeddedByValue()                     {}
 
@@ -218,6 +387,85 @@ func _SongService_UploadSong_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SongService_UploadSongStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SongServiceServer).UploadSongStream(&grpc.GenericServerStream[UploadSongStreamRequest, UploadSongResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SongService_UploadSongStreamServer = grpc.ClientStreamingServer[UploadSongStreamRequest, UploadSongResponse]
+
+func _SongService_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).CreateUploadSession(ctx, req.(*CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongService_GetUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).GetUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_GetUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).GetUploadSession(ctx, req.(*GetUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongService_AppendUploadChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendUploadChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).AppendUploadChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_AppendUploadChunk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).AppendUploadChunk(ctx, req.(*AppendUploadChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongService_FinalizeUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinalizeUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).FinalizeUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_FinalizeUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).FinalizeUploadSession(ctx, req.(*FinalizeUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SongService_StreamSongFile_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(StreamSongFileRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -240,6 +488,24 @@ func _SongService_StreamAlbumCover_Handler(srv interface{}, stream grpc.ServerSt
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SongService_StreamAlbumCoverServer = grpc.ServerStreamingServer[StreamAlbumCoverResponse]
 
+func _SongService_GetSongWaveform_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongWaveformRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).GetSongWaveform(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_GetSongWaveform_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).GetSongWaveform(ctx, req.(*GetSongWaveformRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SongService_GetUserSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserSongsRequest)
 	if err := dec(in); err != nil {
@@ -276,6 +542,24 @@ func _SongService_GetAllSongs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SongService_SearchSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).SearchSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_SearchSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).SearchSongs(ctx, req.(*SearchSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SongService_UpdateSongMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateSongMetadataRequest)
 	if err := dec(in); err != nil {
@@ -312,6 +596,60 @@ func _SongService_DeleteSong_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SongService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongService_RestoreSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).RestoreSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_RestoreSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).RestoreSong(ctx, req.(*RestoreSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SongService_PurgeSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SongServiceServer).PurgeSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SongService_PurgeSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SongServiceServer).PurgeSong(ctx, req.(*PurgeSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SongService_ServiceDesc is the grpc.ServiceDesc for SongService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +661,26 @@ var SongService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UploadSong",
 			Handler:    _SongService_UploadSong_Handler,
 		},
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _SongService_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetUploadSession",
+			Handler:    _SongService_GetUploadSession_Handler,
+		},
+		{
+			MethodName: "AppendUploadChunk",
+			Handler:    _SongService_AppendUploadChunk_Handler,
+		},
+		{
+			MethodName: "FinalizeUploadSession",
+			Handler:    _SongService_FinalizeUploadSession_Handler,
+		},
+		{
+			MethodName: "GetSongWaveform",
+			Handler:    _SongService_GetSongWaveform_Handler,
+		},
 		{
 			MethodName: "GetUserSongs",
 			Handler:    _SongService_GetUserSongs_Handler,
@@ -331,6 +689,10 @@ var SongService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllSongs",
 			Handler:    _SongService_GetAllSongs_Handler,
 		},
+		{
+			MethodName: "SearchSongs",
+			Handler:    _SongService_SearchSongs_Handler,
+		},
 		{
 			MethodName: "UpdateSongMetadata",
 			Handler:    _SongService_UpdateSongMetadata_Handler,
@@ -339,8 +701,25 @@ var SongService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSong",
 			Handler:    _SongService_DeleteSong_Handler,
 		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _SongService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreSong",
+			Handler:    _SongService_RestoreSong_Handler,
+		},
+		{
+			MethodName: "PurgeSong",
+			Handler:    _SongService_PurgeSong_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadSongStream",
+			Handler:       _SongService_UploadSongStream_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "StreamSongFile",
 			Handler:       _SongService_StreamSongFile_Handler,
@@ -0,0 +1,601 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/ingest"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+const (
+	uploadSessionsCollection = storage.UploadSessionsCollection
+	uploadChunksCollection   = "upload_session_chunks"
+
+	maxUploadChunkSize     = 4 * 1024 * 1024
+	uploadSessionIdleTTL   = 24 * time.Hour
+	uploadSessionSweepTick = 10 * time.Minute
+	// uploadSessionLease bounds how long a finalization or the janitor holds a
+	// session, so one that dies midway does not lock the session for good.
+	uploadSessionLease = 5 * time.Minute
+)
+
+// uploadSession is the persisted state of a resumable upload. Acknowledged chunks
+// live in uploadChunksCollection until the session is finalized or expires.
+type uploadSession struct {
+	ID                 primitive.ObjectID `bson:"_id"`
+	UserID             string             `bson:"userId"`
+	Title              string             `bson:"title"`
+	Artist             string             `bson:"artist"`
+	Album              string             `bson:"album"`
+	Description        string             `bson:"description"`
+	SongSize           int64              `bson:"songSize"`
+	SongSHA256         string             `bson:"songSha256"`
+	AlbumCoverID       primitive.ObjectID `bson:"albumCoverID"`
+	AlbumCoverSize     int64              `bson:"albumCoverSize"`
+	AlbumCoverMimeType string             `bson:"albumCoverMimeType"`
+	AlbumCoverSHA256   string             `bson:"albumCoverSha256"`
+	CommittedOffset    int64              `bson:"committedOffset"`
+	FinalizingUntil    time.Time          `bson:"finalizingUntil,omitempty"`
+	// PendingSongID is set while the song a finalization queued has not been
+	// confirmed. Until PendingUntil the session must not publish it again.
+	PendingSongID     primitive.ObjectID `bson:"pendingSongId,omitempty"`
+	PendingSongFileID string             `bson:"pendingSongFileId,omitempty"`
+	PendingUntil      time.Time          `bson:"pendingUntil,omitempty"`
+	CreatedAt         time.Time          `bson:"createdAt"`
+	ExpiresAt         time.Time          `bson:"expiresAt"`
+}
+
+type uploadChunk struct {
+	SessionID primitive.ObjectID `bson:"sessionId"`
+	Offset    int64              `bson:"offset"`
+	Data      []byte             `bson:"data"`
+}
+
+func (u *uploadSession) toProto() *pb.UploadSession {
+	return &pb.UploadSession{
+		SessionId:       u.ID.Hex(),
+		CommittedOffset: u.CommittedOffset,
+		SongSize:        u.SongSize,
+		MaxChunkSize:    maxUploadChunkSize,
+		ExpiresAt:       u.ExpiresAt.Unix(),
+	}
+}
+
+func ensureUploadSessionIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(uploadChunksCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "sessionId", Value: 1}, {Key: "offset", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection(uploadSessionsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "expiresAt", Value: 1}},
+	})
+	return err
+}
+
+func (s *Server) CreateUploadSession(ctx context.Context, req *pb.CreateUploadSessionRequest) (*pb.UploadSession, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is required")
+	}
+	if err := validateStreamMetadata(metadata); err != nil {
+		return nil, err
+	}
+	db := s.mongoClient.Database("musicDB")
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to open GridFS bucket: %v", err)
+	}
+
+	albumCover, err := uploadBlob(bucket, coverBlob, req.GetAlbumCover(), maxAlbumCoverSize)
+	if err != nil {
+		return nil, s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+	if err := processAlbumCover(bucket, albumCover.ID); err != nil {
+		s.deleteBlobFiles(ctx, bucket, albumCover.ID)
+		return nil, s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+	acquiredCover, err := s.acquireBlob(ctx, bucket, albumCover)
+	if err != nil {
+		s.deleteBlobFiles(ctx, bucket, albumCover.ID)
+		return nil, err
+	}
+	albumCover = acquiredCover
+
+	now := time.Now()
+	session := &uploadSession{
+		ID:                 primitive.NewObjectID(),
+		UserID:             claims.UserID,
+		Title:              metadata.GetTitle(),
+		Artist:             metadata.GetArtist(),
+		Album:              metadata.GetAlbum(),
+		Description:        metadata.GetDescription(),
+		SongSize:           metadata.GetSongSize(),
+		SongSHA256:         strings.ToLower(metadata.GetSongSha256()),
+		AlbumCoverID:       albumCover.ID,
+		AlbumCoverSize:     albumCover.Size,
+		AlbumCoverMimeType: albumCover.MimeType,
+		AlbumCoverSHA256:   albumCover.SHA256,
+		CreatedAt:          now,
+		ExpiresAt:          now.Add(uploadSessionIdleTTL),
+	}
+
+	if _, err := db.Collection(uploadSessionsCollection).InsertOne(ctx, session); err != nil {
+		s.releaseBlob(ctx, bucket, albumCover.ID)
+		return nil, status.Errorf(codes.Internal, "failed to create upload session: %v", err)
+	}
+
+	log.Printf("Created upload session %s for user %s (%d bytes)", session.ID.Hex(), claims.UserID, session.SongSize)
+	return session.toProto(), nil
+}
+
+func (s *Server) GetUploadSession(ctx context.Context, req *pb.GetUploadSessionRequest) (*pb.UploadSession, error) {
+	session, err := s.loadUploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return session.toProto(), nil
+}
+
+// AppendUploadChunk accepts only the chunk that starts at the committed offset.
+// Re-sending an already acknowledged chunk is a no-op so clients can retry
+// blindly, as long as it matches what was stored.
+func (s *Server) AppendUploadChunk(ctx context.Context, req *pb.AppendUploadChunkRequest) (*pb.UploadSession, error) {
+	session, err := s.loadUploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := req.GetChunk()
+	offset := req.GetOffset()
+	switch {
+	case len(chunk) == 0:
+		return nil, status.Error(codes.InvalidArgument, "chunk is empty")
+	case len(chunk) > maxUploadChunkSize:
+		return nil, status.Errorf(codes.InvalidArgument, "chunk exceeds the maximum size of %d bytes", maxUploadChunkSize)
+	case offset < session.CommittedOffset:
+		if err := s.matchStoredChunk(ctx, session.ID, offset, chunk); err != nil {
+			return nil, err
+		}
+		return session.toProto(), nil
+	case offset > session.CommittedOffset:
+		return nil, status.Errorf(codes.OutOfRange, "expected offset %d, got %d", session.CommittedOffset, offset)
+	case offset+int64(len(chunk)) > session.SongSize:
+		return nil, status.Errorf(codes.OutOfRange, "chunk ends past the declared song size of %d bytes", session.SongSize)
+	}
+
+	// Reject disallowed content on the first chunk instead of at finalization.
+	if offset == 0 {
+		head := chunk[:min(len(chunk), storage.SniffLen)]
+		if err := songBlob.check(head, storage.SniffContentType(head), session.SongSize); err != nil {
+			return nil, s.quarantine(ctx, session.UserID, session.Title, err)
+		}
+	}
+
+	db := s.mongoClient.Database("musicDB")
+	_, err = db.Collection(uploadChunksCollection).InsertOne(ctx, uploadChunk{
+		SessionID: session.ID,
+		Offset:    offset,
+		Data:      chunk,
+	})
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		return nil, status.Errorf(codes.Internal, "failed to store chunk: %v", err)
+	}
+	if mongo.IsDuplicateKeyError(err) {
+		// A previous attempt stored the chunk but never advanced the offset.
+		if err := s.matchStoredChunk(ctx, session.ID, offset, chunk); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	var updated uploadSession
+	err = db.Collection(uploadSessionsCollection).FindOneAndUpdate(ctx,
+		bson.M{"_id": session.ID, "committedOffset": offset},
+		bson.M{"$set": bson.M{
+			"committedOffset": offset + int64(len(chunk)),
+			"expiresAt":       now.Add(uploadSessionIdleTTL),
+		}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&updated)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return s.GetUploadSession(ctx, &pb.GetUploadSessionRequest{SessionId: session.ID.Hex()})
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to advance upload session: %v", err)
+	}
+
+	return updated.toProto(), nil
+}
+
+func (s *Server) FinalizeUploadSession(ctx context.Context, req *pb.FinalizeUploadSessionRequest) (*pb.UploadSongResponse, error) {
+	session, err := s.loadUploadSession(ctx, req.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	if session.CommittedOffset != session.SongSize {
+		return nil, status.Errorf(codes.FailedPrecondition, "upload incomplete: %d of %d bytes received", session.CommittedOffset, session.SongSize)
+	}
+
+	db := s.mongoClient.Database("musicDB")
+	lease, claimed, err := claimUploadSession(ctx, db, session.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to lock upload session: %v", err)
+	}
+	if !claimed {
+		return nil, status.Error(codes.Aborted, "upload session is already being finalized")
+	}
+	// Stop before the lease runs out and someone else may take the session.
+	ctx, cancel := context.WithDeadline(ctx, lease)
+	defer cancel()
+
+	response, err := s.finalizeUploadSession(ctx, db, session)
+	if err != nil {
+		releaseUploadSession(context.Background(), db, session.ID, lease)
+		return nil, err
+	}
+
+	if cleanupErr := deleteUploadSession(ctx, db, session.ID); cleanupErr != nil {
+		log.Printf("Failed to clean up finalized upload session %s: %v", session.ID.Hex(), cleanupErr)
+	}
+
+	return response, nil
+}
+
+func (s *Server) finalizeUploadSession(ctx context.Context, db *mongo.Database, session *uploadSession) (*pb.UploadSongResponse, error) {
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to open GridFS bucket: %v", err)
+	}
+
+	if !session.PendingSongID.IsZero() {
+		response, err := s.resolvePendingUpload(ctx, db, bucket, session)
+		if response != nil || err != nil {
+			return response, err
+		}
+	}
+
+	songFileID := primitive.NewObjectID()
+	song := newBlobUpload(bucket, songFileID, songBlob, session.SongSize)
+
+	cursor, err := db.Collection(uploadChunksCollection).Find(ctx,
+		bson.M{"sessionId": session.ID},
+		options.Find().SetSort(bson.D{{Key: "offset", Value: 1}}),
+	)
+	if err != nil {
+		song.Abort()
+		return nil, status.Errorf(codes.Internal, "failed to read stored chunks: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var chunk uploadChunk
+		if err := cursor.Decode(&chunk); err != nil {
+			song.Abort()
+			return nil, status.Errorf(codes.Internal, "failed to decode stored chunk: %v", err)
+		}
+		if chunk.Offset != song.size {
+			song.Abort()
+			return nil, status.Errorf(codes.DataLoss, "stored chunks are not contiguous at offset %d", song.size)
+		}
+		if err := song.Write(chunk.Data); err != nil {
+			song.Abort()
+			return nil, s.quarantine(ctx, session.UserID, session.Title, err)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		song.Abort()
+		return nil, status.Errorf(codes.Internal, "failed to read stored chunks: %v", err)
+	}
+
+	if song.size != session.SongSize || song.Sum() != session.SongSHA256 {
+		song.Abort()
+		return nil, status.Error(codes.DataLoss, "song checksum mismatch")
+	}
+	if err := song.Close(); err != nil {
+		return nil, s.quarantine(ctx, session.UserID, session.Title, err)
+	}
+
+	songInfo, err := s.acquireBlob(ctx, bucket, song.Info())
+	if err != nil {
+		s.deleteBlobFiles(ctx, bucket, songFileID)
+		return nil, err
+	}
+
+	metadata := s.newSongMetadata(
+		ctx,
+		bucket,
+		session.Title,
+		session.Artist,
+		session.Album,
+		session.Description,
+		session.UserID,
+		songInfo,
+		blobInfo{
+			ID:       session.AlbumCoverID,
+			Size:     session.AlbumCoverSize,
+			SHA256:   session.AlbumCoverSHA256,
+			MimeType: session.AlbumCoverMimeType,
+		},
+	)
+	// The session keeps its reference on the cover until it is deleted, so a
+	// failed finalization can be retried.
+	err = s.publishStoredSong(ctx, bucket, metadata, session.AlbumCoverID, true)
+	if err == errIngestPending {
+		markUploadPending(ctx, db, session.ID, metadata)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UploadSongResponse{
+		Message: "Song uploaded successfully",
+		Status:  "success",
+		SongId:  metadata.SongFileID,
+		CoverId: metadata.AlbumCoverID,
+	}, nil
+}
+
+// markUploadPending records the song a finalization queued without a
+// confirmation. The session now holds the references of that song's blobs
+// until it is written or known to be dead-lettered.
+func markUploadPending(ctx context.Context, db *mongo.Database, sessionID primitive.ObjectID, metadata *pb.SongMetadata) {
+	songID, _ := primitive.ObjectIDFromHex(metadata.XId)
+	coverID, _ := primitive.ObjectIDFromHex(metadata.AlbumCoverID)
+
+	_, err := db.Collection(uploadSessionsCollection).UpdateOne(ctx, bson.M{"_id": sessionID}, bson.M{"$set": bson.M{
+		"pendingSongId":     songID,
+		"pendingSongFileId": metadata.SongFileID,
+		"pendingUntil":      time.Now().Add(ingest.DeliveryWindow()),
+		"albumCoverID":      coverID,
+	}})
+	if err != nil {
+		log.Printf("Failed to record pending upload session %s: %v", sessionID.Hex(), err)
+	}
+}
+
+// resolvePendingUpload checks on the song a previous finalization queued. It
+// returns the upload response once the song is written and errIngestPending
+// while it may still be. Once the delivery window has passed without a write
+// the song was dead-lettered: its file is released and the session is cleared
+// for another finalization, signalled by a nil response and error.
+func (s *Server) resolvePendingUpload(ctx context.Context, db *mongo.Database, bucket *gridfs.Bucket, session *uploadSession) (*pb.UploadSongResponse, error) {
+	written, err := db.Collection(storage.SongsCollection).CountDocuments(ctx, bson.M{"_id": session.PendingSongID}, options.Count().SetLimit(1))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to look up queued song: %v", err)
+	}
+	if written > 0 {
+		return &pb.UploadSongResponse{
+			Message: "Song uploaded successfully",
+			Status:  "success",
+			SongId:  session.PendingSongFileID,
+			CoverId: session.AlbumCoverID.Hex(),
+		}, nil
+	}
+	if time.Now().Before(session.PendingUntil) {
+		return nil, errIngestPending
+	}
+
+	cleared, err := db.Collection(uploadSessionsCollection).UpdateOne(ctx,
+		bson.M{"_id": session.ID, "pendingSongId": session.PendingSongID},
+		bson.M{"$unset": bson.M{"pendingSongId": "", "pendingSongFileId": "", "pendingUntil": ""}},
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to clear queued song: %v", err)
+	}
+	if cleared.ModifiedCount > 0 {
+		s.releaseBlobHex(ctx, bucket, session.PendingSongFileID)
+		log.Printf("Queued song %s of upload session %s was not saved; the session can be finalized again", session.PendingSongID.Hex(), session.ID.Hex())
+	}
+	session.PendingSongID = primitive.NilObjectID
+	return nil, nil
+}
+
+// matchStoredChunk checks a re-sent chunk against the one stored at offset.
+func (s *Server) matchStoredChunk(ctx context.Context, sessionID primitive.ObjectID, offset int64, chunk []byte) error {
+	var existing uploadChunk
+	err := s.mongoClient.Database("musicDB").Collection(uploadChunksCollection).
+		FindOne(ctx, bson.M{"sessionId": sessionID, "offset": offset}).Decode(&existing)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return status.Errorf(codes.OutOfRange, "offset %d is not the start of an acknowledged chunk", offset)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to load stored chunk: %v", err)
+	}
+	if !bytes.Equal(existing.Data, chunk) {
+		return status.Errorf(codes.AlreadyExists, "a different chunk was already stored at offset %d", offset)
+	}
+	return nil
+}
+
+// claimUploadSession takes the session's lease unless another holder's lease
+// is still running. The returned lease identifies the holder on release.
+func claimUploadSession(ctx context.Context, db *mongo.Database, sessionID primitive.ObjectID) (time.Time, bool, error) {
+	now := time.Now()
+	// Mongo keeps milliseconds; the lease must compare equal once stored.
+	lease := now.Add(uploadSessionLease).Truncate(time.Millisecond)
+
+	claimed, err := db.Collection(uploadSessionsCollection).UpdateOne(ctx,
+		bson.M{"_id": sessionID, "finalizingUntil": bson.M{"$not": bson.M{"$gt": now}}},
+		bson.M{"$set": bson.M{"finalizingUntil": lease}},
+	)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return lease, claimed.ModifiedCount > 0, nil
+}
+
+// releaseUploadSession gives up a lease taken by claimUploadSession, unless it
+// has expired and been taken over since.
+func releaseUploadSession(ctx context.Context, db *mongo.Database, sessionID primitive.ObjectID, lease time.Time) {
+	_, err := db.Collection(uploadSessionsCollection).UpdateOne(ctx,
+		bson.M{"_id": sessionID, "finalizingUntil": lease},
+		bson.M{"$unset": bson.M{"finalizingUntil": ""}},
+	)
+	if err != nil {
+		log.Printf("Failed to unlock upload session %s: %v", sessionID.Hex(), err)
+	}
+}
+
+func (s *Server) loadUploadSession(ctx context.Context, sessionID string) (*uploadSession, error) {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session ID")
+	}
+
+	var session uploadSession
+	err = s.mongoClient.Database("musicDB").Collection(uploadSessionsCollection).FindOne(ctx, bson.M{"_id": objectID}).Decode(&session)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, status.Error(codes.NotFound, "upload session not found or expired")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load upload session: %v", err)
+	}
+
+	if session.UserID != claims.UserID {
+		return nil, status.Error(codes.NotFound, "upload session not found or expired")
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, status.Error(codes.NotFound, "upload session not found or expired")
+	}
+
+	return &session, nil
+}
+
+func deleteUploadSession(ctx context.Context, db *mongo.Database, sessionID primitive.ObjectID) error {
+	if _, err := db.Collection(uploadChunksCollection).DeleteMany(ctx, bson.M{"sessionId": sessionID}); err != nil {
+		return err
+	}
+	_, err := db.Collection(uploadSessionsCollection).DeleteOne(ctx, bson.M{"_id": sessionID})
+	return err
+}
+
+// runUploadSessionJanitor periodically discards sessions that have been idle past
+// their expiry, together with their stored chunks and album cover.
+func (s *Server) runUploadSessionJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.expireUploadSessions(); err != nil {
+			log.Printf("Upload session janitor failed: %v", err)
+		}
+	}
+}
+
+func (s *Server) expireUploadSessions() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	db := s.mongoClient.Database("musicDB")
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return fmt.Errorf("failed to open GridFS bucket: %v", err)
+	}
+
+	if err := s.resolvePendingUploads(ctx, db, bucket); err != nil {
+		return err
+	}
+
+	// A session whose queued song may still be written holds that song's
+	// references and is left for the next sweep.
+	cursor, err := db.Collection(uploadSessionsCollection).Find(ctx, bson.M{
+		"expiresAt":    bson.M{"$lt": time.Now()},
+		"pendingUntil": bson.M{"$not": bson.M{"$gt": time.Now()}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find expired sessions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var session uploadSession
+		if err := cursor.Decode(&session); err != nil {
+			return fmt.Errorf("failed to decode session: %v", err)
+		}
+
+		// A session being finalized is left to the finalization, or to a
+		// later sweep once its lease has run out.
+		lease, claimed, err := claimUploadSession(ctx, db, session.ID)
+		if err != nil || !claimed {
+			continue
+		}
+		if err := s.releaseBlob(ctx, bucket, session.AlbumCoverID); err != nil {
+			log.Printf("Failed to delete album cover of expired session %s: %v", session.ID.Hex(), err)
+			releaseUploadSession(ctx, db, session.ID, lease)
+			continue
+		}
+		if err := deleteUploadSession(ctx, db, session.ID); err != nil {
+			log.Printf("Failed to delete expired session %s: %v", session.ID.Hex(), err)
+			continue
+		}
+
+		log.Printf("Expired upload session %s of user %s", session.ID.Hex(), session.UserID)
+	}
+
+	return cursor.Err()
+}
+
+// resolvePendingUploads settles sessions whose queued song is past its
+// delivery window: written songs end the session, dead-lettered ones leave it
+// to be finalized again or to expire.
+func (s *Server) resolvePendingUploads(ctx context.Context, db *mongo.Database, bucket *gridfs.Bucket) error {
+	cursor, err := db.Collection(uploadSessionsCollection).Find(ctx, bson.M{"pendingUntil": bson.M{"$lt": time.Now()}})
+	if err != nil {
+		return fmt.Errorf("failed to find pending sessions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var session uploadSession
+		if err := cursor.Decode(&session); err != nil {
+			return fmt.Errorf("failed to decode session: %v", err)
+		}
+
+		lease, claimed, err := claimUploadSession(ctx, db, session.ID)
+		if err != nil || !claimed {
+			continue
+		}
+		response, err := s.resolvePendingUpload(ctx, db, bucket, &session)
+		if err != nil {
+			log.Printf("Failed to resolve pending session %s: %v", session.ID.Hex(), err)
+		}
+		if response == nil {
+			releaseUploadSession(ctx, db, session.ID, lease)
+			continue
+		}
+		if err := deleteUploadSession(ctx, db, session.ID); err != nil {
+			log.Printf("Failed to delete finalized session %s: %v", session.ID.Hex(), err)
+		}
+	}
+
+	return cursor.Err()
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+const (
+	trashRetentionEnv = "TRASH_RETENTION"
+
+	trashPurgeTick  = 10 * time.Minute
+	trashPurgeBatch = 100
+	// trashPurgerUser is recorded as the initiator of deletions started by the purger.
+	trashPurgerUser = "trash-purger"
+)
+
+// trashRetention is how long a song stays in the trash before it is purged,
+// overridable through trashRetentionEnv as a Go duration such as "720h".
+var trashRetention = 30 * 24 * time.Hour
+
+func loadTrashRetention() error {
+	value := os.Getenv(trashRetentionEnv)
+	if value == "" {
+		return nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		return fmt.Errorf("%s must be a positive duration, got %q", trashRetentionEnv, value)
+	}
+	trashRetention = parsed
+	return nil
+}
+
+func (s *Server) DeleteSong(ctx context.Context, req *pb.DeleteSongRequest) (*pb.DeleteSongResponse, error) {
+	msg, err := natsrpc.Request(ctx, s.natsConn, "songs.delete", []byte(req.GetSongId()), BearerFromContext(ctx), 10*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to request NATS: %v", err)
+	}
+
+	var response pb.DeleteSongResponse
+	err = proto.Unmarshal(msg.Data, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+
+	return &response, nil
+}
+
+func (s *Server) RestoreSong(ctx context.Context, req *pb.RestoreSongRequest) (*pb.RestoreSongResponse, error) {
+	msg, err := natsrpc.Request(ctx, s.natsConn, "songs.restore", []byte(req.GetSongId()), BearerFromContext(ctx), 10*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to request NATS: %v", err)
+	}
+
+	var response pb.RestoreSongResponse
+	err = proto.Unmarshal(msg.Data, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+
+	return &response, nil
+}
+
+func (s *Server) ListTrash(ctx context.Context, req *pb.ListTrashRequest) (*pb.ListTrashResponse, error) {
+	natsReq, err := proto.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %v", err)
+	}
+
+	msg, err := natsrpc.Request(ctx, s.natsConn, "songs.trash", natsReq, BearerFromContext(ctx), 10*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to list trash via NATS: %v", err)
+	}
+
+	var response pb.ListTrashResponse
+	err = proto.Unmarshal(msg.Data, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %v", err)
+	}
+
+	return &response, nil
+}
+
+// runTrashPurger periodically purges songs that have been in the trash longer
+// than trashRetention.
+func (s *Server) runTrashPurger(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := s.purgeExpiredTrash(); err != nil {
+			log.Printf("Trash purger failed: %v", err)
+		}
+	}
+}
+
+// purgeExpiredTrash runs the same deletion as PurgeSong. Having no caller
+// token to send songs.purge with, it deletes the song document itself, under
+// the same condition that the song is still trashed and expired.
+func (s *Server) purgeExpiredTrash() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	songs := s.mongoClient.Database("musicDB").Collection(storage.SongsCollection)
+	expired := bson.M{"$lte": primitive.NewDateTimeFromTime(time.Now().Add(-trashRetention))}
+
+	cursor, err := songs.Find(ctx, bson.M{"deletedAt": expired}, options.Find().
+		SetProjection(bson.M{"songFileID": 1, "albumCoverID": 1}).
+		SetLimit(trashPurgeBatch),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to find expired songs: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var song struct {
+			ID           primitive.ObjectID `bson:"_id"`
+			SongFileID   string             `bson:"songFileID"`
+			AlbumCoverID string             `bson:"albumCoverID"`
+		}
+		if err := cursor.Decode(&song); err != nil {
+			return fmt.Errorf("failed to decode song: %v", err)
+		}
+
+		deletion, err := s.startSongDeletion(ctx, song.ID, trashPurgerUser, song.SongFileID, song.AlbumCoverID)
+		if err != nil {
+			log.Printf("Failed to start purge of song %s: %v", song.ID.Hex(), err)
+			continue
+		}
+
+		result, err := songs.DeleteOne(ctx, bson.M{"_id": song.ID, "deletedAt": expired})
+		if err != nil {
+			// The deletion worker finds out whether the document is gone.
+			log.Printf("Failed to purge song %s: %v", song.ID.Hex(), err)
+			continue
+		}
+		if result.DeletedCount == 0 {
+			s.finishSongDeletion(ctx, deletion, deletionCompensated, errors.New("song was restored"))
+			continue
+		}
+
+		s.completeSongDeletion(ctx, deletion)
+		log.Printf("Purged song %s from the trash", song.ID.Hex())
+	}
+	return cursor.Err()
+}
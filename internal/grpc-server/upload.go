@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+// blobUpload writes chunks into a GridFS upload stream while enforcing a size
+// limit, hashing the content and sniffing its MIME type. The stream is opened
+// once enough bytes have arrived to sniff, so content of a type the kind does
+// not allow never reaches GridFS and the type can be stored in the file
+// metadata.
+type blobUpload struct {
+	bucket   *gridfs.Bucket
+	id       primitive.ObjectID
+	kind     blobKind
+	stream   *gridfs.UploadStream
+	head     []byte
+	hash     hash.Hash
+	size     int64
+	maxSize  int64
+	mimeType string
+}
+
+// blobInfo describes a stored GridFS file.
+type blobInfo struct {
+	ID       primitive.ObjectID
+	Size     int64
+	SHA256   string
+	MimeType string
+}
+
+func newBlobUpload(bucket *gridfs.Bucket, id primitive.ObjectID, kind blobKind, maxSize int64) *blobUpload {
+	return &blobUpload{bucket: bucket, id: id, kind: kind, hash: sha256.New(), maxSize: maxSize}
+}
+
+func (u *blobUpload) Write(chunk []byte) error {
+	if u.size+int64(len(chunk)) > u.maxSize {
+		return &uploadRejection{
+			Kind:     u.kind.name,
+			Reason:   fmt.Sprintf("%s exceeds the maximum size of %d bytes", u.kind.name, u.maxSize),
+			MimeType: u.mimeType,
+			Size:     u.size + int64(len(chunk)),
+		}
+	}
+	u.hash.Write(chunk)
+	u.size += int64(len(chunk))
+
+	if u.stream == nil {
+		u.head = append(u.head, chunk...)
+		if len(u.head) < storage.SniffLen {
+			return nil
+		}
+		return u.open()
+	}
+
+	if _, err := u.stream.Write(chunk); err != nil {
+		return status.Errorf(codes.Internal, "failed to write to GridFS: %v", err)
+	}
+	return nil
+}
+
+func (u *blobUpload) open() error {
+	u.mimeType = storage.SniffContentType(u.head)
+	if err := u.kind.check(u.head, u.mimeType, u.size); err != nil {
+		return err
+	}
+
+	stream, err := u.bucket.OpenUploadStreamWithID(u.id, u.kind.filename(u.id, u.mimeType),
+		options.GridFSUpload().SetMetadata(bson.M{"contentType": u.mimeType}))
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to open upload stream: %v", err)
+	}
+	u.stream = stream
+
+	if _, err := u.stream.Write(u.head); err != nil {
+		return status.Errorf(codes.Internal, "failed to write to GridFS: %v", err)
+	}
+	u.head = nil
+	return nil
+}
+
+// Close flushes the upload. Empty uploads are stored without a type check:
+// they are how a song without a cover is represented.
+func (u *blobUpload) Close() error {
+	if u.stream == nil && u.size == 0 {
+		stream, err := u.bucket.OpenUploadStreamWithID(u.id, u.kind.filename(u.id, ""))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to open upload stream: %v", err)
+		}
+		u.stream = stream
+	}
+	if u.stream == nil {
+		if err := u.open(); err != nil {
+			return err
+		}
+	}
+	if err := u.stream.Close(); err != nil {
+		return status.Errorf(codes.Internal, "failed to finish upload: %v", err)
+	}
+	return nil
+}
+
+func (u *blobUpload) Abort() {
+	if u.stream != nil {
+		u.stream.Abort()
+	}
+}
+
+func (u *blobUpload) Sum() string {
+	return hex.EncodeToString(u.hash.Sum(nil))
+}
+
+func (u *blobUpload) Info() blobInfo {
+	return blobInfo{ID: u.id, Size: u.size, SHA256: u.Sum(), MimeType: u.mimeType}
+}
+
+// uploadBlob stores data that is already fully in memory.
+func uploadBlob(bucket *gridfs.Bucket, kind blobKind, data []byte, maxSize int64) (blobInfo, error) {
+	upload := newBlobUpload(bucket, primitive.NewObjectID(), kind, maxSize)
+	if err := upload.Write(data); err != nil {
+		upload.Abort()
+		return blobInfo{}, err
+	}
+	if err := upload.Close(); err != nil {
+		return blobInfo{}, err
+	}
+	return upload.Info(), nil
+}
+
+// newSongMetadata builds the metadata published for a freshly stored song,
+// completed from the audio file itself.
+func (s *Server) newSongMetadata(ctx context.Context, bucket *gridfs.Bucket, title, artist, album, description, uploadedBy string, song, cover blobInfo) *pb.SongMetadata {
+	metadata := &pb.SongMetadata{
+		XId:                primitive.NewObjectID().Hex(),
+		Title:              title,
+		Artist:             artist,
+		Album:              album,
+		Description:        description,
+		UploadedBy:         uploadedBy,
+		SongFileID:         song.ID.Hex(),
+		AlbumCoverID:       cover.ID.Hex(),
+		UploadedAt:         time.Now().UnixMilli(),
+		SongFileSize:       song.Size,
+		SongMimeType:       song.MimeType,
+		SongSha256:         song.SHA256,
+		AlbumCoverSize:     cover.Size,
+		AlbumCoverMimeType: cover.MimeType,
+		AlbumCoverSha256:   cover.SHA256,
+	}
+	s.inspectSong(ctx, bucket, metadata)
+	return metadata
+}
+
+func validateStreamMetadata(metadata *pb.UploadSongStreamMetadata) error {
+	if metadata.GetSongSize() <= 0 || metadata.GetSongSize() > maxSongSize {
+		return status.Errorf(codes.InvalidArgument, "song_size must be between 1 and %d bytes", maxSongSize)
+	}
+	if _, err := hex.DecodeString(metadata.GetSongSha256()); err != nil || len(metadata.GetSongSha256()) != sha256.Size*2 {
+		return status.Error(codes.InvalidArgument, "song_sha256 must be a hex-encoded SHA-256 digest")
+	}
+	return nil
+}
+
+func (s *Server) UploadSongStream(stream pb.SongService_UploadSongStreamServer) error {
+	ctx := stream.Context()
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to receive metadata: %v", err)
+	}
+	metadata := first.GetMetadata()
+	if metadata == nil {
+		return status.Error(codes.InvalidArgument, "first message must carry metadata")
+	}
+	if err := validateStreamMetadata(metadata); err != nil {
+		return err
+	}
+
+	db := s.mongoClient.Database("musicDB")
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to open GridFS bucket: %v", err)
+	}
+
+	songFileID := primitive.NewObjectID()
+	song := newBlobUpload(bucket, songFileID, songBlob, metadata.GetSongSize())
+
+	albumCoverID := primitive.NewObjectID()
+	albumCover := newBlobUpload(bucket, albumCoverID, coverBlob, maxAlbumCoverSize)
+
+	abort := func(err error) error {
+		song.Abort()
+		albumCover.Abort()
+		return s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return abort(status.Errorf(codes.Canceled, "upload interrupted: %v", err))
+		}
+
+		switch payload := req.GetPayload().(type) {
+		case *pb.UploadSongStreamRequest_SongChunk:
+			err = song.Write(payload.SongChunk)
+		case *pb.UploadSongStreamRequest_AlbumCoverChunk:
+			err = albumCover.Write(payload.AlbumCoverChunk)
+		default:
+			err = status.Error(codes.InvalidArgument, "metadata may only be sent in the first message")
+		}
+		if err != nil {
+			return abort(err)
+		}
+	}
+
+	if song.size != metadata.GetSongSize() {
+		return abort(status.Errorf(codes.InvalidArgument, "received %d bytes, expected %d", song.size, metadata.GetSongSize()))
+	}
+	if !strings.EqualFold(song.Sum(), metadata.GetSongSha256()) {
+		return abort(status.Error(codes.DataLoss, "song checksum mismatch"))
+	}
+
+	if err := song.Close(); err != nil {
+		albumCover.Abort()
+		return s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+	if err := albumCover.Close(); err != nil {
+		s.deleteBlobFiles(ctx, bucket, songFileID)
+		return s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+	if err := processAlbumCover(bucket, albumCoverID); err != nil {
+		s.deleteBlobFiles(ctx, bucket, songFileID)
+		s.deleteBlobFiles(ctx, bucket, albumCoverID)
+		return s.quarantine(ctx, claims.UserID, metadata.GetTitle(), err)
+	}
+
+	songInfo, albumCoverInfo, err := s.acquireSongBlobs(ctx, bucket, song.Info(), albumCover.Info())
+	if err != nil {
+		return err
+	}
+
+	songMetadata := s.newSongMetadata(
+		ctx,
+		bucket,
+		metadata.GetTitle(),
+		metadata.GetArtist(),
+		metadata.GetAlbum(),
+		metadata.GetDescription(),
+		claims.UserID,
+		songInfo,
+		albumCoverInfo,
+	)
+	if err := s.publishStoredSong(ctx, bucket, songMetadata, albumCoverInfo.ID, false); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(&pb.UploadSongResponse{
+		Message: fmt.Sprintf("Song uploaded successfully (%d bytes)", song.size),
+		Status:  "success",
+		SongId:  songMetadata.SongFileID,
+		CoverId: songMetadata.AlbumCoverID,
+	})
+}
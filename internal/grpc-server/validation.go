@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxSongSizeEnv       = "MAX_SONG_SIZE_BYTES"
+	maxAlbumCoverSizeEnv = "MAX_ALBUM_COVER_SIZE_BYTES"
+
+	quarantineCollection = "upload_quarantine"
+)
+
+// Upload size limits, overridable through maxSongSizeEnv and
+// maxAlbumCoverSizeEnv.
+var (
+	maxSongSize       int64 = 1 << 30
+	maxAlbumCoverSize int64 = 10 * 1024 * 1024
+)
+
+// blobKind describes what may be stored for one kind of upload: the sniffed
+// MIME types that are accepted and the file extension used for each.
+type blobKind struct {
+	name       string
+	extensions map[string]string
+}
+
+var (
+	songBlob = blobKind{
+		name: "song",
+		extensions: map[string]string{
+			"audio/mpeg": "mp3",
+			"audio/flac": "flac",
+			"audio/ogg":  "ogg",
+			"audio/wav":  "wav",
+			"audio/mp4":  "m4a",
+		},
+	}
+	coverBlob = blobKind{
+		name: "cover",
+		extensions: map[string]string{
+			"image/jpeg": "jpg",
+			"image/png":  "png",
+			"image/gif":  "gif",
+			"image/webp": "webp",
+		},
+	}
+)
+
+// filename builds the GridFS filename for a blob from its ID and sniffed type.
+// Client-supplied names are never used.
+func (k blobKind) filename(id primitive.ObjectID, mimeType string) string {
+	if ext, ok := k.extensions[mimeType]; ok {
+		return fmt.Sprintf("%s-%s.%s", k.name, id.Hex(), ext)
+	}
+	return fmt.Sprintf("%s-%s", k.name, id.Hex())
+}
+
+func (k blobKind) check(head []byte, mimeType string, size int64) error {
+	if _, ok := k.extensions[mimeType]; ok {
+		return nil
+	}
+	return &uploadRejection{
+		Kind:     k.name,
+		Reason:   fmt.Sprintf("%s content type %q is not allowed", k.name, mimeType),
+		MimeType: mimeType,
+		Size:     size,
+		Head:     head,
+	}
+}
+
+// loadUploadLimits applies the size limits configured in the environment.
+func loadUploadLimits() error {
+	for env, limit := range map[string]*int64{
+		maxSongSizeEnv:       &maxSongSize,
+		maxAlbumCoverSizeEnv: &maxAlbumCoverSize,
+	} {
+		value := os.Getenv(env)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return fmt.Errorf("%s must be a positive number of bytes, got %q", env, value)
+		}
+		*limit = parsed
+	}
+	return nil
+}
+
+// uploadRejection is returned when uploaded content is refused. It carries
+// the InvalidArgument status to the client and is recorded in the quarantine
+// collection for moderators.
+type uploadRejection struct {
+	Kind     string
+	Reason   string
+	MimeType string
+	Size     int64
+	Head     []byte
+}
+
+func (r *uploadRejection) Error() string {
+	return r.Reason
+}
+
+func (r *uploadRejection) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, r.Reason)
+}
+
+// quarantineRecord is what moderators see for a rejected upload. Head holds the
+// leading bytes that were sniffed; the rest of the content is not kept.
+type quarantineRecord struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	UserID     string             `bson:"userId"`
+	Title      string             `bson:"title"`
+	Kind       string             `bson:"kind"`
+	Reason     string             `bson:"reason"`
+	MimeType   string             `bson:"mimeType,omitempty"`
+	Size       int64              `bson:"size,omitempty"`
+	Head       []byte             `bson:"head,omitempty"`
+	RejectedAt time.Time          `bson:"rejectedAt"`
+}
+
+// quarantine records err when it is an upload rejection and returns it
+// unchanged, so call sites can wrap their error returns.
+func (s *Server) quarantine(ctx context.Context, userID, title string, err error) error {
+	rejection, ok := err.(*uploadRejection)
+	if !ok {
+		return err
+	}
+
+	record := quarantineRecord{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		Title:      title,
+		Kind:       rejection.Kind,
+		Reason:     rejection.Reason,
+		MimeType:   rejection.MimeType,
+		Size:       rejection.Size,
+		Head:       rejection.Head,
+		RejectedAt: time.Now(),
+	}
+
+	// The caller's context may already be cancelled when the stream broke.
+	insertCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+
+	if _, insertErr := s.mongoClient.Database("musicDB").Collection(quarantineCollection).InsertOne(insertCtx, record); insertErr != nil {
+		log.Printf("Failed to quarantine rejected %s upload from %s: %v", rejection.Kind, userID, insertErr)
+	}
+	log.Printf("Rejected %s upload from %s: %s", rejection.Kind, userID, rejection.Reason)
+	return err
+}
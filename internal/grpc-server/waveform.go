@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/media"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+const (
+	waveformsCollection = storage.WaveformsCollection
+	waveformTimeout     = 10 * time.Minute
+	// maxConcurrentWaveforms bounds how many songs are decoded at once; each
+	// decoder holds its own buffers and a CPU core.
+	maxConcurrentWaveforms = 2
+)
+
+// waveformSlots is a semaphore of maxConcurrentWaveforms; uploads past the
+// limit wait for a slot.
+var waveformSlots = make(chan struct{}, maxConcurrentWaveforms)
+
+// waveformResolutions are the bucket counts stored for every song: a coarse one
+// for list rows and a fine one for the full-width scrubber.
+var waveformResolutions = []int{256, 2048}
+
+// songWaveform is keyed by the GridFS ID of the song file.
+type songWaveform struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Resolutions []waveformPeaks    `bson:"resolutions"`
+	CreatedAt   time.Time          `bson:"createdAt"`
+}
+
+type waveformPeaks struct {
+	Buckets int `bson:"buckets"`
+	// Peaks are int8 values stored as raw bytes.
+	Peaks []byte `bson:"peaks"`
+}
+
+// generateWaveform decodes a stored song and saves its peak envelopes. It runs
+// in the background after an upload; failures and formats that cannot be
+// decoded only mean the song has no waveform, and so does a decoder panic on a
+// malformed file.
+func (s *Server) generateWaveform(songFileID, mimeType string) {
+	objectID, err := primitive.ObjectIDFromHex(songFileID)
+	if err != nil {
+		return
+	}
+
+	waveformSlots <- struct{}{}
+	defer func() { <-waveformSlots }()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic while generating waveform for %s: %v", songFileID, r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), waveformTimeout)
+	defer cancel()
+
+	// Deduplicated uploads share the file, and its waveform, with an earlier song.
+	db := s.mongoClient.Database("musicDB")
+	if count, err := db.Collection(waveformsCollection).CountDocuments(ctx, bson.M{"_id": objectID}); err == nil && count > 0 {
+		return
+	}
+
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		log.Printf("Failed to open GridFS bucket for waveform of %s: %v", songFileID, err)
+		return
+	}
+
+	reader, err := storage.NewReadSeeker(bucket, objectID)
+	if err != nil {
+		log.Printf("Failed to open song %s for waveform: %v", songFileID, err)
+		return
+	}
+	defer reader.Close()
+
+	waveforms, err := media.GenerateWaveforms(reader, mimeType, waveformResolutions...)
+	if errors.Is(err, media.ErrUnsupportedFormat) {
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to generate waveform for %s: %v", songFileID, err)
+		return
+	}
+
+	doc := songWaveform{ID: objectID, CreatedAt: time.Now()}
+	for _, waveform := range waveforms {
+		peaks := make([]byte, len(waveform.Peaks))
+		for i, peak := range waveform.Peaks {
+			peaks[i] = byte(peak)
+		}
+		doc.Resolutions = append(doc.Resolutions, waveformPeaks{Buckets: waveform.Buckets, Peaks: peaks})
+	}
+
+	_, err = db.Collection(waveformsCollection).ReplaceOne(ctx, bson.M{"_id": objectID}, doc, options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Printf("Failed to save waveform for %s: %v", songFileID, err)
+	}
+}
+
+func (s *Server) GetSongWaveform(ctx context.Context, req *pb.GetSongWaveformRequest) (*pb.GetSongWaveformResponse, error) {
+	msg, err := natsrpc.Request(ctx, s.natsConn, "songs.get", []byte(req.GetSongId()), BearerFromContext(ctx), 10*time.Second)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get song: %v", err)
+	}
+
+	var song pb.SongMetadata
+	if err := proto.Unmarshal(msg.Data, &song); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unmarshal song: %v", err)
+	}
+
+	songFileID, err := primitive.ObjectIDFromHex(song.GetSongFileID())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "waveform not available")
+	}
+
+	var doc songWaveform
+	err = s.mongoClient.Database("musicDB").Collection(waveformsCollection).FindOne(ctx, bson.M{"_id": songFileID}).Decode(&doc)
+	if err == mongo.ErrNoDocuments || err == nil && len(doc.Resolutions) == 0 {
+		return nil, status.Error(codes.NotFound, "waveform not available")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load waveform: %v", err)
+	}
+
+	selected := doc.Resolutions[len(doc.Resolutions)-1]
+	available := make([]int32, 0, len(doc.Resolutions))
+	for _, resolution := range doc.Resolutions {
+		available = append(available, int32(resolution.Buckets))
+	}
+	if req.GetResolution() > 0 {
+		for _, resolution := range doc.Resolutions {
+			if resolution.Buckets >= int(req.GetResolution()) {
+				selected = resolution
+				break
+			}
+		}
+	}
+
+	peaks := make([]int32, len(selected.Peaks))
+	for i, peak := range selected.Peaks {
+		peaks[i] = int32(int8(peak))
+	}
+
+	return &pb.GetSongWaveformResponse{
+		SongId:               req.GetSongId(),
+		Buckets:              int32(selected.Buckets),
+		Peaks:                peaks,
+		AvailableResolutions: available,
+	}, nil
+}
@@ -0,0 +1,212 @@
+// Package ingest carries uploaded song metadata from the gRPC server to the
+// songs service over JetStream, so an upload survives the songs service being
+// down and is only reported as successful once it has been written.
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"google.golang.org/grpc/codes"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+)
+
+const (
+	StreamName    = "SONGS_INGEST"
+	UploadSubject = "songs.upload"
+	ConsumerName  = "songs-service"
+
+	DeadLetterStreamName = "SONGS_INGEST_DLQ"
+	DeadLetterSubject    = "songs.upload.dead"
+	deadLetterMaxAge     = 30 * 24 * time.Hour
+
+	// ConfirmHeader names the inbox on which the consumer reports the final
+	// outcome of an upload: an empty reply once the song is written, or a
+	// natsrpc error once it has been dead-lettered.
+	ConfirmHeader = "Confirm-To"
+	// ErrorHeader records on a dead-lettered message why it was given up.
+	ErrorHeader = "Ingest-Error"
+
+	// MaxDeliveriesSubject carries the advisory the server publishes when an
+	// upload's last delivery goes unacknowledged, e.g. because the songs
+	// service crashed while writing it. Retry never sees such deliveries.
+	MaxDeliveriesSubject = "$JS.EVENT.ADVISORY.CONSUMER.MAX_DELIVERIES." + StreamName + "." + ConsumerName
+)
+
+// AckWait is how long a delivery may go unacknowledged before it is
+// redelivered. It has to outlast the songs service's processing timeout.
+const AckWait = 30 * time.Second
+
+// RetryBackoff is the delay before each redelivery of a failed upload. A
+// message is dead-lettered once it has been delivered one more time than
+// there are intervals.
+var RetryBackoff = []time.Duration{
+	time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	2 * time.Minute,
+	10 * time.Minute,
+}
+
+// EnsureStreams creates or updates the upload stream and its dead-letter stream.
+func EnsureStreams(ctx context.Context, js jetstream.JetStream) error {
+	_, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      StreamName,
+		Subjects:  []string{UploadSubject},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:     DeadLetterStreamName,
+		Subjects: []string{DeadLetterSubject},
+		Storage:  jetstream.FileStorage,
+		MaxAge:   deadLetterMaxAge,
+	})
+	return err
+}
+
+// ConsumerConfig is the durable consumer the songs service reads uploads with.
+// BackOff covers deliveries that were never acknowledged, e.g. because the
+// service crashed, and DeadLetterExhausted the last of them; explicit failures
+// are redelivered through Retry. The server takes the first BackOff step as
+// the ack wait, so no step is shorter than AckWait.
+func ConsumerConfig() jetstream.ConsumerConfig {
+	backoff := make([]time.Duration, len(RetryBackoff))
+	for i, delay := range RetryBackoff {
+		backoff[i] = max(delay, AckWait)
+	}
+
+	return jetstream.ConsumerConfig{
+		Durable:       ConsumerName,
+		FilterSubject: UploadSubject,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       AckWait,
+		MaxDeliver:    len(RetryBackoff) + 1,
+		BackOff:       backoff,
+	}
+}
+
+// DeliveryWindow bounds how long an upload can stay queued before it is either
+// written or dead-lettered.
+func DeliveryWindow() time.Duration {
+	window := time.Duration(len(RetryBackoff)+1) * AckWait
+	for _, delay := range RetryBackoff {
+		window += max(delay, AckWait)
+	}
+	return window
+}
+
+// Retry schedules m for redelivery after the backoff for its delivery count,
+// or dead-letters it with reason once the last delivery has failed.
+func Retry(ctx context.Context, js jetstream.JetStream, nc *nats.Conn, m jetstream.Msg, reason string) error {
+	meta, err := m.Metadata()
+	if err != nil {
+		return err
+	}
+	if meta.NumDelivered > uint64(len(RetryBackoff)) {
+		return DeadLetter(ctx, js, nc, m, codes.Unavailable, reason)
+	}
+	return m.NakWithDelay(RetryBackoff[meta.NumDelivered-1])
+}
+
+// DeadLetter moves m to the dead-letter stream, terminates it and reports the
+// failure to the uploader. If the dead-letter stream cannot be written, m is
+// left for redelivery instead of being dropped.
+func DeadLetter(ctx context.Context, js jetstream.JetStream, nc *nats.Conn, m jetstream.Msg, code codes.Code, reason string) error {
+	if err := publishDeadLetter(ctx, js, m.Data(), m.Headers(), code, reason); err != nil {
+		m.NakWithDelay(RetryBackoff[len(RetryBackoff)-1])
+		return err
+	}
+	if err := m.Term(); err != nil {
+		return err
+	}
+	return Confirm(nc, m, code, reason)
+}
+
+// maxDeliveriesAdvisory is the part of the server's max deliveries advisory
+// that identifies the upload.
+type maxDeliveriesAdvisory struct {
+	Stream     string `json:"stream"`
+	Consumer   string `json:"consumer"`
+	StreamSeq  uint64 `json:"stream_seq"`
+	Deliveries uint64 `json:"deliveries"`
+}
+
+// DeadLetterExhausted dead-letters the upload named by a MaxDeliveriesSubject
+// advisory and reports the failure to the uploader. The server keeps such a
+// message in the stream without delivering it again, so it is deleted once
+// it has been copied to the dead-letter stream. An upload that is no longer
+// in the stream was acknowledged late and is left alone.
+func DeadLetterExhausted(ctx context.Context, js jetstream.JetStream, nc *nats.Conn, advisory []byte) error {
+	var event maxDeliveriesAdvisory
+	if err := json.Unmarshal(advisory, &event); err != nil {
+		return fmt.Errorf("failed to decode max deliveries advisory: %v", err)
+	}
+	if event.Stream != StreamName || event.Consumer != ConsumerName {
+		return nil
+	}
+
+	stream, err := js.Stream(ctx, StreamName)
+	if err != nil {
+		return err
+	}
+	m, err := stream.GetMsg(ctx, event.StreamSeq)
+	if errors.Is(err, jetstream.ErrMsgNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("upload was not acknowledged after %d deliveries", event.Deliveries)
+	if err := publishDeadLetter(ctx, js, m.Data, m.Header, codes.Unavailable, reason); err != nil {
+		return err
+	}
+	if err := stream.DeleteMsg(ctx, event.StreamSeq); err != nil {
+		return err
+	}
+	return confirm(nc, m.Header.Get(ConfirmHeader), codes.Unavailable, reason)
+}
+
+func publishDeadLetter(ctx context.Context, js jetstream.JetStream, data []byte, header nats.Header, code codes.Code, reason string) error {
+	dead := nats.NewMsg(DeadLetterSubject)
+	dead.Data = data
+	for key, values := range header {
+		dead.Header[key] = values
+	}
+	dead.Header.Set(ErrorHeader, reason)
+	dead.Header.Set(natsrpc.StatusHeader, strconv.Itoa(int(code)))
+
+	_, err := js.PublishMsg(ctx, dead)
+	return err
+}
+
+// Confirm reports the outcome of m on the inbox named by its ConfirmHeader.
+// codes.OK confirms the write. Nothing is sent when the uploader did not ask.
+func Confirm(nc *nats.Conn, m jetstream.Msg, code codes.Code, message string) error {
+	return confirm(nc, m.Headers().Get(ConfirmHeader), code, message)
+}
+
+func confirm(nc *nats.Conn, inbox string, code codes.Code, message string) error {
+	if inbox == "" {
+		return nil
+	}
+
+	reply := nats.NewMsg(inbox)
+	if code != codes.OK {
+		reply.Header.Set(natsrpc.StatusHeader, strconv.Itoa(int(code)))
+		reply.Data = []byte(message)
+	}
+	return nc.PublishMsg(reply)
+}
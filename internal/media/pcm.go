@@ -0,0 +1,240 @@
+package media
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"math"
+
+	"github.com/hajimehoshi/go-mp3"
+	"github.com/mewkiz/flac"
+)
+
+// pcmSource yields decoded audio downmixed to mono, scaled to [-1, 1].
+type pcmSource interface {
+	SampleRate() int
+	// ReadMono appends up to limit samples to buf. It returns io.EOF once the
+	// stream is exhausted.
+	ReadMono(buf []float32, limit int) ([]float32, error)
+}
+
+// openPCM picks a decoder for the sniffed mimeType of r. Only WAV, MP3 and
+// FLAC can be decoded; anything else returns ErrUnsupportedFormat.
+func openPCM(r io.ReadSeeker, mimeType string) (pcmSource, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	buffered := bufio.NewReaderSize(r, 64*1024)
+
+	switch mimeType {
+	case "audio/flac":
+		return newFLACSource(buffered)
+	case "audio/wav":
+		return newWAVSource(buffered)
+	case "audio/mpeg":
+		return newMP3Source(buffered)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type mp3Source struct {
+	decoder *mp3.Decoder
+	frame   []byte
+}
+
+func newMP3Source(r io.Reader) (*mp3Source, error) {
+	decoder, err := mp3.NewDecoder(r)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return &mp3Source{decoder: decoder, frame: make([]byte, 4*4096)}, nil
+}
+
+func (s *mp3Source) SampleRate() int {
+	return s.decoder.SampleRate()
+}
+
+// ReadMono averages the interleaved 16-bit stereo go-mp3 always produces.
+func (s *mp3Source) ReadMono(buf []float32, limit int) ([]float32, error) {
+	want := limit * 4
+	if want > len(s.frame) {
+		want = len(s.frame)
+	}
+	n, err := io.ReadFull(s.decoder, s.frame[:want])
+	for i := 0; i+4 <= n; i += 4 {
+		left := int16(binary.LittleEndian.Uint16(s.frame[i:]))
+		right := int16(binary.LittleEndian.Uint16(s.frame[i+2:]))
+		buf = append(buf, (float32(left)+float32(right))/2/math.MaxInt16)
+	}
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return buf, err
+}
+
+type flacSource struct {
+	stream  *flac.Stream
+	scale   float32
+	pending []float32
+}
+
+func newFLACSource(r io.Reader) (*flacSource, error) {
+	stream, err := flac.New(r)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return &flacSource{
+		stream: stream,
+		scale:  float32(int64(1) << (stream.Info.BitsPerSample - 1)),
+	}, nil
+}
+
+func (s *flacSource) SampleRate() int {
+	return int(s.stream.Info.SampleRate)
+}
+
+func (s *flacSource) ReadMono(buf []float32, limit int) ([]float32, error) {
+	for len(s.pending) == 0 {
+		frame, err := s.stream.ParseNext()
+		if err != nil {
+			return buf, err
+		}
+		channels := len(frame.Subframes)
+		for i := 0; i < int(frame.BlockSize); i++ {
+			var sum float32
+			for _, subframe := range frame.Subframes {
+				sum += float32(subframe.Samples[i])
+			}
+			s.pending = append(s.pending, sum/float32(channels)/s.scale)
+		}
+	}
+
+	n := limit
+	if n > len(s.pending) {
+		n = len(s.pending)
+	}
+	buf = append(buf, s.pending[:n]...)
+	s.pending = s.pending[n:]
+	return buf, nil
+}
+
+type wavSource struct {
+	r          io.Reader
+	remaining  int64
+	sampleRate int
+	channels   int
+	bytesPer   int
+	float      bool
+	block      []byte
+}
+
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+func newWAVSource(r io.Reader) (*wavSource, error) {
+	var riff [12]byte
+	if _, err := io.ReadFull(r, riff[:]); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	source := &wavSource{r: r}
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, ErrUnsupportedFormat
+		}
+		length := int64(binary.LittleEndian.Uint32(header[4:8]))
+
+		switch string(header[:4]) {
+		case "fmt ":
+			format, err := readWAVFormat(r, length)
+			if err != nil {
+				return nil, err
+			}
+			if length%2 == 1 {
+				if _, err := io.CopyN(io.Discard, r, 1); err != nil {
+					return nil, ErrUnsupportedFormat
+				}
+			}
+			formatTag := binary.LittleEndian.Uint16(format[0:2])
+			if formatTag == wavFormatExtensible && length >= 26 {
+				formatTag = binary.LittleEndian.Uint16(format[24:26])
+			}
+			source.channels = int(binary.LittleEndian.Uint16(format[2:4]))
+			source.sampleRate = int(binary.LittleEndian.Uint32(format[4:8]))
+			source.bytesPer = int(binary.LittleEndian.Uint16(format[14:16])+7) / 8
+			source.float = formatTag == wavFormatFloat
+
+			if formatTag != wavFormatPCM && formatTag != wavFormatFloat {
+				return nil, ErrUnsupportedFormat
+			}
+			if source.float && source.bytesPer != 4 || source.bytesPer < 1 || source.bytesPer > 4 || source.channels < 1 {
+				return nil, ErrUnsupportedFormat
+			}
+		case "data":
+			if source.channels == 0 {
+				return nil, ErrUnsupportedFormat
+			}
+			source.remaining = length
+			source.block = make([]byte, 4096*source.channels*source.bytesPer)
+			return source, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, length+length%2); err != nil {
+				return nil, ErrUnsupportedFormat
+			}
+		}
+	}
+}
+
+func (s *wavSource) SampleRate() int {
+	return s.sampleRate
+}
+
+func (s *wavSource) ReadMono(buf []float32, limit int) ([]float32, error) {
+	frameSize := s.channels * s.bytesPer
+	want := int64(limit * frameSize)
+	if want > int64(len(s.block)) {
+		want = int64(len(s.block))
+	}
+	if want > s.remaining {
+		want = s.remaining - s.remaining%int64(frameSize)
+	}
+	if want <= 0 {
+		return buf, io.EOF
+	}
+
+	n, err := io.ReadFull(s.r, s.block[:want])
+	s.remaining -= int64(n)
+	for i := 0; i+frameSize <= n; i += frameSize {
+		var sum float32
+		for ch := 0; ch < s.channels; ch++ {
+			sum += s.sample(s.block[i+ch*s.bytesPer:])
+		}
+		buf = append(buf, sum/float32(s.channels))
+	}
+	if err != nil {
+		return buf, io.EOF
+	}
+	return buf, nil
+}
+
+func (s *wavSource) sample(b []byte) float32 {
+	switch {
+	case s.float:
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case s.bytesPer == 1:
+		// 8-bit WAV is unsigned.
+		return (float32(b[0]) - 128) / 128
+	case s.bytesPer == 2:
+		return float32(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+	case s.bytesPer == 3:
+		v := int32(b[0]) | int32(b[1])<<8 | int32(int8(b[2]))<<16
+		return float32(v) / (1 << 23)
+	default:
+		return float32(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+	}
+}
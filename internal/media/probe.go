@@ -0,0 +1,444 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrUnsupportedFormat is returned by Probe for containers it cannot parse.
+var ErrUnsupportedFormat = errors.New("unsupported audio format")
+
+// AudioInfo describes the stream inside an audio file. Bitrate is in bits per
+// second and is averaged over the whole file for variable bitrate streams.
+type AudioInfo struct {
+	Duration   time.Duration
+	SampleRate int
+	Channels   int
+	Bitrate    int
+}
+
+// Probe reads the container headers of an MP3, FLAC, WAV or OGG (Vorbis, Opus)
+// file without decoding the audio. size is the total length of r.
+func Probe(r io.ReadSeeker, size int64) (*AudioInfo, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 12)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	var info *AudioInfo
+	var err error
+	switch {
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		info, err = probeFLAC(r)
+	case bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		info, err = probeWAV(r)
+	case bytes.HasPrefix(head, []byte("OggS")):
+		info, err = probeOgg(r, size)
+	case bytes.HasPrefix(head, []byte("ID3")), isMP3Frame(head):
+		info, err = probeMP3(r, size, head)
+	default:
+		// MP4 and anything else unknown would otherwise be scanned for a
+		// stray frame sync and reported as MP3.
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Bitrate == 0 && info.Duration > 0 {
+		info.Bitrate = int(float64(size*8) / info.Duration.Seconds())
+	}
+	return info, nil
+}
+
+func samplesToDuration(samples int64, sampleRate int) time.Duration {
+	if sampleRate <= 0 || samples <= 0 {
+		return 0
+	}
+	return time.Duration(float64(samples) / float64(sampleRate) * float64(time.Second))
+}
+
+func probeFLAC(r io.ReadSeeker) (*AudioInfo, error) {
+	if _, err := r.Seek(4, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, ErrUnsupportedFormat
+		}
+		blockType := header[0] & 0x7F
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+
+		if blockType == 0 {
+			streamInfo := make([]byte, 34)
+			if length < 34 {
+				return nil, ErrUnsupportedFormat
+			}
+			if _, err := io.ReadFull(r, streamInfo); err != nil {
+				return nil, ErrUnsupportedFormat
+			}
+
+			// 20 bits sample rate, 3 bits channels-1, 5 bits bits-per-sample-1,
+			// 36 bits total samples.
+			packed := binary.BigEndian.Uint64(streamInfo[10:18])
+			sampleRate := int(packed >> 44)
+			channels := int(packed>>41&0x7) + 1
+			totalSamples := int64(packed & 0xFFFFFFFFF)
+
+			return &AudioInfo{
+				Duration:   samplesToDuration(totalSamples, sampleRate),
+				SampleRate: sampleRate,
+				Channels:   channels,
+			}, nil
+		}
+
+		if header[0]&0x80 != 0 {
+			return nil, ErrUnsupportedFormat
+		}
+		if _, err := r.Seek(length, io.SeekCurrent); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// maxWAVFormatSize bounds the "fmt " chunk. WAVE_FORMAT_EXTENSIBLE, the largest
+// defined layout, takes 40 bytes.
+const maxWAVFormatSize = 64
+
+// readWAVFormat reads a "fmt " chunk body of the declared length, refusing
+// lengths outside what any real format chunk uses before allocating.
+func readWAVFormat(r io.Reader, length int64) ([]byte, error) {
+	if length < 16 || length > maxWAVFormatSize {
+		return nil, ErrUnsupportedFormat
+	}
+	format := make([]byte, length)
+	if _, err := io.ReadFull(io.LimitReader(r, length), format); err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	return format, nil
+}
+
+func probeWAV(r io.ReadSeeker) (*AudioInfo, error) {
+	var info *AudioInfo
+	var byteRate int64
+
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return nil, ErrUnsupportedFormat
+		}
+		length := int64(binary.LittleEndian.Uint32(header[4:8]))
+
+		switch string(header[:4]) {
+		case "fmt ":
+			format, err := readWAVFormat(r, length)
+			if err != nil {
+				return nil, err
+			}
+			byteRate = int64(binary.LittleEndian.Uint32(format[8:12]))
+			info = &AudioInfo{
+				Channels:   int(binary.LittleEndian.Uint16(format[2:4])),
+				SampleRate: int(binary.LittleEndian.Uint32(format[4:8])),
+				Bitrate:    int(byteRate * 8),
+			}
+		case "data":
+			if info == nil || byteRate == 0 {
+				return nil, ErrUnsupportedFormat
+			}
+			info.Duration = time.Duration(float64(length) / float64(byteRate) * float64(time.Second))
+			return info, nil
+		default:
+			if _, err := r.Seek(length, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
+
+		if length%2 == 1 {
+			if _, err := r.Seek(1, io.SeekCurrent); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// oggTailSize bounds how much of the end of the file is scanned for the last
+// page, which carries the final granule position.
+const oggTailSize = 64 * 1024
+
+func probeOgg(r io.ReadSeeker, size int64) (*AudioInfo, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	// The identification header fits in the first page.
+	page := make([]byte, 27+255+64)
+	n, _ := io.ReadFull(r, page)
+	page = page[:n]
+	if len(page) < 28 {
+		return nil, ErrUnsupportedFormat
+	}
+	serial := binary.LittleEndian.Uint32(page[14:18])
+	segments := int(page[26])
+	if len(page) < 27+segments {
+		return nil, ErrUnsupportedFormat
+	}
+	packet := page[27+segments:]
+
+	var info AudioInfo
+	var granuleRate int
+	var preSkip int64
+	switch {
+	case len(packet) >= 16 && bytes.HasPrefix(packet, []byte("\x01vorbis")):
+		info.Channels = int(packet[11])
+		info.SampleRate = int(binary.LittleEndian.Uint32(packet[12:16]))
+		granuleRate = info.SampleRate
+	case len(packet) >= 16 && bytes.HasPrefix(packet, []byte("OpusHead")):
+		info.Channels = int(packet[9])
+		preSkip = int64(binary.LittleEndian.Uint16(packet[10:12]))
+		info.SampleRate = int(binary.LittleEndian.Uint32(packet[12:16]))
+		// Opus granule positions always count 48 kHz samples.
+		granuleRate = 48000
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	tailStart := size - oggTailSize
+	if tailStart < 0 {
+		tailStart = 0
+	}
+	if _, err := r.Seek(tailStart, io.SeekStart); err != nil {
+		return nil, err
+	}
+	tail, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := bytes.LastIndex(tail, []byte("OggS")); i >= 0; i = bytes.LastIndex(tail[:i], []byte("OggS")) {
+		if len(tail)-i < 27 || binary.LittleEndian.Uint32(tail[i+14:i+18]) != serial {
+			continue
+		}
+		granule := int64(binary.LittleEndian.Uint64(tail[i+6 : i+14]))
+		if granule < 0 {
+			continue
+		}
+		info.Duration = samplesToDuration(granule-preSkip, granuleRate)
+		break
+	}
+
+	return &info, nil
+}
+
+var (
+	mp3Bitrates = [2][3][16]int{
+		// MPEG-1: layer I, II, III
+		{
+			{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+			{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+		},
+		// MPEG-2 and 2.5: layer I, II, III
+		{
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		},
+	}
+	mp3SampleRates = [3]int{44100, 48000, 32000}
+)
+
+// mp3Frame is a decoded MPEG audio frame header.
+type mp3Frame struct {
+	mpeg1           bool
+	layer           int
+	bitrate         int
+	sampleRate      int
+	channels        int
+	samplesPerFrame int
+	sideInfoSize    int
+	length          int
+}
+
+func parseMP3Frame(header []byte) (mp3Frame, bool) {
+	if len(header) < 4 || header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return mp3Frame{}, false
+	}
+
+	version := header[1] >> 3 & 0x3
+	layerBits := header[1] >> 1 & 0x3
+	bitrateIndex := header[2] >> 4
+	sampleRateIndex := header[2] >> 2 & 0x3
+	if version == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return mp3Frame{}, false
+	}
+
+	frame := mp3Frame{
+		mpeg1: version == 3,
+		layer: 4 - int(layerBits),
+	}
+
+	table := 1
+	frame.sampleRate = mp3SampleRates[sampleRateIndex]
+	switch version {
+	case 3:
+		table = 0
+	case 2:
+		frame.sampleRate /= 2
+	case 0:
+		frame.sampleRate /= 4
+	}
+	frame.bitrate = mp3Bitrates[table][frame.layer-1][bitrateIndex] * 1000
+
+	frame.channels = 2
+	if header[3]>>6 == 3 {
+		frame.channels = 1
+	}
+
+	switch {
+	case frame.layer == 1:
+		frame.samplesPerFrame = 384
+	case frame.layer == 2 || frame.mpeg1:
+		frame.samplesPerFrame = 1152
+	default:
+		frame.samplesPerFrame = 576
+	}
+
+	padding := int(header[2] >> 1 & 0x1)
+	if frame.layer == 1 {
+		frame.length = (12*frame.bitrate/frame.sampleRate + padding) * 4
+	} else {
+		frame.length = frame.samplesPerFrame/8*frame.bitrate/frame.sampleRate + padding
+	}
+
+	switch {
+	case frame.mpeg1 && frame.channels == 1:
+		frame.sideInfoSize = 17
+	case frame.mpeg1:
+		frame.sideInfoSize = 32
+	case frame.channels == 1:
+		frame.sideInfoSize = 9
+	default:
+		frame.sideInfoSize = 17
+	}
+
+	return frame, true
+}
+
+func isMP3Frame(header []byte) bool {
+	_, ok := parseMP3Frame(header)
+	return ok
+}
+
+// mp3SyncWindow bounds how far past the ID3v2 tag we look for the first frame.
+const mp3SyncWindow = 64 * 1024
+
+func probeMP3(r io.ReadSeeker, size int64, head []byte) (*AudioInfo, error) {
+	var audioStart int64
+	if bytes.HasPrefix(head, []byte("ID3")) && len(head) >= 10 {
+		tagSize := int64(head[6]&0x7F)<<21 | int64(head[7]&0x7F)<<14 | int64(head[8]&0x7F)<<7 | int64(head[9]&0x7F)
+		audioStart = 10 + tagSize
+		if head[5]&0x10 != 0 {
+			audioStart += 10
+		}
+	}
+
+	if _, err := r.Seek(audioStart, io.SeekStart); err != nil {
+		return nil, err
+	}
+	window := make([]byte, mp3SyncWindow)
+	n, _ := io.ReadFull(r, window)
+	window = window[:n]
+
+	for i := 0; i+4 <= len(window); i++ {
+		frame, ok := parseMP3Frame(window[i:])
+		if !ok {
+			continue
+		}
+		// Require the following frame to line up, to skip false syncs in
+		// leftover tag data.
+		if next := i + frame.length; next+4 <= len(window) {
+			if _, ok := parseMP3Frame(window[next:]); !ok {
+				continue
+			}
+		}
+
+		info := &AudioInfo{SampleRate: frame.sampleRate, Channels: frame.channels}
+		audioStart += int64(i)
+		audioSize := size - audioStart
+		if hasID3v1(r, size) {
+			audioSize -= 128
+		}
+
+		if frames, bytesCount, ok := vbrHeader(window[i:], frame); ok {
+			samples := int64(frames) * int64(frame.samplesPerFrame)
+			info.Duration = samplesToDuration(samples, frame.sampleRate)
+			if bytesCount > 0 {
+				audioSize = int64(bytesCount)
+			}
+			if info.Duration > 0 {
+				info.Bitrate = int(float64(audioSize*8) / info.Duration.Seconds())
+			}
+			return info, nil
+		}
+
+		// Constant bitrate: every frame has the first frame's bitrate.
+		info.Bitrate = frame.bitrate
+		info.Duration = time.Duration(float64(audioSize*8) / float64(frame.bitrate) * float64(time.Second))
+		return info, nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// vbrHeader reads the frame and byte counts from a Xing/Info or VBRI header
+// stored in the first frame.
+func vbrHeader(data []byte, frame mp3Frame) (frames, byteCount uint32, ok bool) {
+	xing := 4 + frame.sideInfoSize
+	if len(data) >= xing+16 {
+		tag := string(data[xing : xing+4])
+		if tag == "Xing" || tag == "Info" {
+			flags := binary.BigEndian.Uint32(data[xing+4 : xing+8])
+			offset := xing + 8
+			if flags&0x1 != 0 {
+				frames = binary.BigEndian.Uint32(data[offset : offset+4])
+				offset += 4
+			}
+			if flags&0x2 != 0 && len(data) >= offset+4 {
+				byteCount = binary.BigEndian.Uint32(data[offset : offset+4])
+			}
+			return frames, byteCount, frames > 0
+		}
+	}
+
+	const vbri = 4 + 32
+	if len(data) >= vbri+18 && string(data[vbri:vbri+4]) == "VBRI" {
+		byteCount = binary.BigEndian.Uint32(data[vbri+10 : vbri+14])
+		frames = binary.BigEndian.Uint32(data[vbri+14 : vbri+18])
+		return frames, byteCount, frames > 0
+	}
+
+	return 0, 0, false
+}
+
+func hasID3v1(r io.ReadSeeker, size int64) bool {
+	if size < 128 {
+		return false
+	}
+	if _, err := r.Seek(size-128, io.SeekStart); err != nil {
+		return false
+	}
+	var tag [3]byte
+	if _, err := io.ReadFull(r, tag[:]); err != nil {
+		return false
+	}
+	return string(tag[:]) == "TAG"
+}
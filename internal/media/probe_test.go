@@ -0,0 +1,252 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mp3Header is an MPEG-1 layer III frame header: 128 kbps, 44.1 kHz, stereo,
+// no padding. Such frames are 417 bytes long.
+var mp3Header = []byte{0xFF, 0xFB, 0x90, 0x00}
+
+const mp3FrameLength = 417
+
+// mp3Fixture builds count empty frames. first, if set, is written into the
+// first frame right after the 32-byte side info, where Xing and VBRI headers
+// live.
+func mp3Fixture(count int, first []byte) []byte {
+	var b bytes.Buffer
+	for i := 0; i < count; i++ {
+		frame := make([]byte, mp3FrameLength)
+		copy(frame, mp3Header)
+		if i == 0 {
+			copy(frame[4+32:], first)
+		}
+		b.Write(frame)
+	}
+	return b.Bytes()
+}
+
+func xingHeader(frames, byteCount uint32) []byte {
+	b := []byte("Xing")
+	b = binary.BigEndian.AppendUint32(b, 0x3)
+	b = binary.BigEndian.AppendUint32(b, frames)
+	return binary.BigEndian.AppendUint32(b, byteCount)
+}
+
+func vbriHeader(frames, byteCount uint32) []byte {
+	b := []byte("VBRI")
+	// Version, delay and quality.
+	b = append(b, make([]byte, 6)...)
+	b = binary.BigEndian.AppendUint32(b, byteCount)
+	return binary.BigEndian.AppendUint32(b, frames)
+}
+
+func id3Tag(size int) []byte {
+	tag := []byte{'I', 'D', '3', 4, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(tag, make([]byte, size)...)
+}
+
+func flacFixture(sampleRate, channels int, totalSamples int64) []byte {
+	b := []byte("fLaC")
+	// Last metadata block, type STREAMINFO, 34 bytes.
+	b = append(b, 0x80, 0, 0, 34)
+	streamInfo := make([]byte, 34)
+	packed := uint64(sampleRate)<<44 | uint64(channels-1)<<41 | uint64(15)<<36 | uint64(totalSamples)
+	binary.BigEndian.PutUint64(streamInfo[10:18], packed)
+	return append(b, streamInfo...)
+}
+
+func oggPage(serial uint32, granule uint64, packet []byte) []byte {
+	b := []byte("OggS")
+	b = append(b, 0, 0)
+	b = binary.LittleEndian.AppendUint64(b, granule)
+	b = binary.LittleEndian.AppendUint32(b, serial)
+	// Sequence number and CRC are not checked.
+	b = append(b, make([]byte, 8)...)
+	b = append(b, 1, byte(len(packet)))
+	return append(b, packet...)
+}
+
+func oggFixture(identification []byte, pages ...[]byte) []byte {
+	b := oggPage(7, 0, identification)
+	b = append(b, make([]byte, 1000)...)
+	for _, page := range pages {
+		b = append(b, page...)
+	}
+	return b
+}
+
+func vorbisIdentification(channels byte, sampleRate uint32) []byte {
+	b := []byte("\x01vorbis")
+	b = append(b, 0, 0, 0, 0, channels)
+	b = binary.LittleEndian.AppendUint32(b, sampleRate)
+	return append(b, make([]byte, 14)...)
+}
+
+func opusIdentification(channels byte, preSkip uint16, sampleRate uint32) []byte {
+	b := []byte("OpusHead")
+	b = append(b, 1, channels)
+	b = binary.LittleEndian.AppendUint16(b, preSkip)
+	b = binary.LittleEndian.AppendUint32(b, sampleRate)
+	return append(b, 0, 0, 0)
+}
+
+// wavFixture builds a 16-bit PCM WAV whose "fmt " chunk declares fmtLength
+// bytes, followed by data bytes of silence.
+func wavFixture(fmtLength uint32, sampleRate uint32, channels uint16, data int) []byte {
+	b := []byte("RIFF")
+	b = binary.LittleEndian.AppendUint32(b, uint32(36+data))
+	b = append(b, "WAVEfmt "...)
+	b = binary.LittleEndian.AppendUint32(b, fmtLength)
+	b = binary.LittleEndian.AppendUint16(b, 1)
+	b = binary.LittleEndian.AppendUint16(b, channels)
+	b = binary.LittleEndian.AppendUint32(b, sampleRate)
+	b = binary.LittleEndian.AppendUint32(b, sampleRate*uint32(channels)*2)
+	b = binary.LittleEndian.AppendUint16(b, channels*2)
+	b = binary.LittleEndian.AppendUint16(b, 16)
+	b = append(b, "data"...)
+	b = binary.LittleEndian.AppendUint32(b, uint32(data))
+	return append(b, make([]byte, data)...)
+}
+
+func TestProbe(t *testing.T) {
+	cbr := mp3Fixture(100, nil)
+
+	cases := []struct {
+		name string
+		file []byte
+		want AudioInfo
+	}{
+		{
+			name: "mp3 cbr",
+			file: cbr,
+			want: AudioInfo{Duration: 2606250 * time.Microsecond, SampleRate: 44100, Channels: 2, Bitrate: 128000},
+		},
+		{
+			name: "mp3 cbr with id3v2",
+			file: append(id3Tag(1000), cbr...),
+			want: AudioInfo{Duration: 2606250 * time.Microsecond, SampleRate: 44100, Channels: 2, Bitrate: 128000},
+		},
+		{
+			name: "mp3 xing",
+			file: mp3Fixture(10, xingHeader(1000, 417000)),
+			// 1000 frames of 1152 samples.
+			want: AudioInfo{Duration: 26122448 * time.Microsecond, SampleRate: 44100, Channels: 2, Bitrate: 127706},
+		},
+		{
+			name: "mp3 vbri",
+			file: mp3Fixture(10, vbriHeader(500, 200000)),
+			want: AudioInfo{Duration: 13061224 * time.Microsecond, SampleRate: 44100, Channels: 2, Bitrate: 122500},
+		},
+		{
+			name: "flac streaminfo",
+			file: flacFixture(44100, 2, 441000),
+			want: AudioInfo{Duration: 10 * time.Second, SampleRate: 44100, Channels: 2, Bitrate: 33},
+		},
+		{
+			name: "wav",
+			file: wavFixture(16, 8000, 1, 16000),
+			want: AudioInfo{Duration: time.Second, SampleRate: 8000, Channels: 1, Bitrate: 128000},
+		},
+		{
+			name: "vorbis last granule",
+			file: oggFixture(vorbisIdentification(2, 44100),
+				oggPage(7, 44100, []byte{0}),
+				oggPage(7, 3*44100, []byte{0}),
+				// A page of another stream must not be taken as the last one.
+				oggPage(9, 100*44100, []byte{0}),
+			),
+			want: AudioInfo{Duration: 3 * time.Second, SampleRate: 44100, Channels: 2, Bitrate: 3053},
+		},
+		{
+			name: "opus pre-skip",
+			file: oggFixture(opusIdentification(2, 312, 44100),
+				oggPage(7, 2*48000+312, []byte{0}),
+			),
+			// Opus granules count 48 kHz samples whatever the input rate.
+			want: AudioInfo{Duration: 2 * time.Second, SampleRate: 44100, Channels: 2, Bitrate: 4304},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info, err := Probe(bytes.NewReader(c.file), int64(len(c.file)))
+			if err != nil {
+				t.Fatalf("Probe failed: %v", err)
+			}
+			if diff := info.Duration - c.want.Duration; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("Duration = %v, want %v", info.Duration, c.want.Duration)
+			}
+			if info.SampleRate != c.want.SampleRate || info.Channels != c.want.Channels {
+				t.Errorf("SampleRate, Channels = %d, %d, want %d, %d", info.SampleRate, info.Channels, c.want.SampleRate, c.want.Channels)
+			}
+			// Averages over the whole file are only checked loosely.
+			if diff := info.Bitrate - c.want.Bitrate; diff < -c.want.Bitrate/100-1 || diff > c.want.Bitrate/100+1 {
+				t.Errorf("Bitrate = %d, want %d", info.Bitrate, c.want.Bitrate)
+			}
+		})
+	}
+}
+
+func TestProbeRejects(t *testing.T) {
+	// An MP4 whose payload happens to contain an MPEG frame sync.
+	mp4 := append([]byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"), mp3Fixture(3, nil)...)
+
+	cases := []struct {
+		name string
+		file []byte
+	}{
+		{"mp4", mp4},
+		{"text", []byte("just some text, not audio at all")},
+		{"too short", []byte("ID3")},
+		{"flac without streaminfo", append([]byte("fLaC"), 0x81, 0, 0, 0)},
+		{"ogg unknown codec", oggFixture([]byte("\x80theora and some padding"))},
+		{"wav huge fmt", wavFixture(0xFFFFFFF0, 8000, 1, 16)},
+		{"wav oversized fmt", wavFixture(maxWAVFormatSize+2, 8000, 1, 16)},
+		{"wav short fmt", wavFixture(8, 8000, 1, 16)},
+		{"wav truncated fmt", wavFixture(16, 8000, 1, 0)[:28]},
+		{"wav without data", wavFixture(16, 8000, 1, 0)[:36]},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			info, err := Probe(bytes.NewReader(c.file), int64(len(c.file)))
+			if !errors.Is(err, ErrUnsupportedFormat) {
+				t.Errorf("Probe = %+v, %v, want %v", info, err, ErrUnsupportedFormat)
+			}
+		})
+	}
+}
+
+func TestGenerateWaveformsWAV(t *testing.T) {
+	waveforms, err := GenerateWaveforms(bytes.NewReader(wavFixture(16, 8000, 1, 16000)), "audio/wav", 8)
+	if err != nil {
+		t.Fatalf("GenerateWaveforms failed: %v", err)
+	}
+	if len(waveforms) != 1 {
+		t.Fatalf("got %d waveforms, want 1", len(waveforms))
+	}
+
+	cases := []struct {
+		name string
+		file []byte
+	}{
+		{"huge fmt", wavFixture(0xFFFFFFF0, 8000, 1, 16)},
+		{"oversized fmt", wavFixture(maxWAVFormatSize+2, 8000, 1, 16)},
+		{"short fmt", wavFixture(8, 8000, 1, 16)},
+		{"truncated fmt", wavFixture(16, 8000, 1, 0)[:28]},
+		{"zero sample rate", wavFixture(16, 0, 1, 16)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := GenerateWaveforms(bytes.NewReader(c.file), "audio/wav", 8); err == nil {
+				t.Error("GenerateWaveforms succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package media
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/dhowden/tag"
+)
+
+// ErrNoTags is returned by ReadTags when the file carries no recognised tags.
+var ErrNoTags = errors.New("no tags found")
+
+// Tags holds the fields we care about from ID3v1/ID3v2, Vorbis comments
+// (OGG and FLAC) and MP4 atoms.
+type Tags struct {
+	Title   string
+	Artist  string
+	Album   string
+	Genre   string
+	Year    int
+	Track   int
+	Picture *Picture
+}
+
+// Picture is an embedded cover image.
+type Picture struct {
+	MIMEType string
+	Data     []byte
+}
+
+func ReadTags(r io.ReadSeeker) (*Tags, error) {
+	metadata, err := tag.ReadFrom(r)
+	if errors.Is(err, tag.ErrNoTagsFound) {
+		return nil, ErrNoTags
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tags := &Tags{
+		Title:  strings.TrimSpace(metadata.Title()),
+		Artist: strings.TrimSpace(metadata.Artist()),
+		Album:  strings.TrimSpace(metadata.Album()),
+		Genre:  strings.TrimSpace(metadata.Genre()),
+		Year:   metadata.Year(),
+	}
+	if tags.Artist == "" {
+		tags.Artist = strings.TrimSpace(metadata.AlbumArtist())
+	}
+	tags.Track, _ = metadata.Track()
+
+	if picture := metadata.Picture(); picture != nil && len(picture.Data) > 0 {
+		tags.Picture = &Picture{MIMEType: picture.MIMEType, Data: picture.Data}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,93 @@
+package media
+
+import (
+	"errors"
+	"io"
+	"math"
+)
+
+// waveformBlockRate is how many peak blocks per second are kept while decoding,
+// before the blocks are folded into the requested resolutions.
+const waveformBlockRate = 100
+
+var errEmptyAudio = errors.New("no audio samples decoded")
+
+// Waveform is a min/max peak envelope of a track split into Buckets equal
+// slices. Peaks holds a min and a max value per bucket, interleaved and scaled
+// to [-127, 127].
+type Waveform struct {
+	Buckets int
+	Peaks   []int8
+}
+
+// GenerateWaveforms decodes r, whose sniffed content type is mimeType, and
+// returns one waveform per requested resolution, in the same order.
+func GenerateWaveforms(r io.ReadSeeker, mimeType string, resolutions ...int) ([]Waveform, error) {
+	source, err := openPCM(r, mimeType)
+	if err != nil {
+		return nil, err
+	}
+
+	blockSize := source.SampleRate() / waveformBlockRate
+	if blockSize < 1 {
+		return nil, ErrUnsupportedFormat
+	}
+
+	var mins, maxs []float32
+	samples := make([]float32, 0, blockSize)
+	for {
+		samples, err = source.ReadMono(samples, blockSize-len(samples))
+		if len(samples) == blockSize || (err != nil && len(samples) > 0) {
+			lo, hi := samples[0], samples[0]
+			for _, sample := range samples[1:] {
+				lo = min(lo, sample)
+				hi = max(hi, sample)
+			}
+			mins = append(mins, lo)
+			maxs = append(maxs, hi)
+			samples = samples[:0]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(mins) == 0 {
+		return nil, errEmptyAudio
+	}
+
+	waveforms := make([]Waveform, 0, len(resolutions))
+	for _, buckets := range resolutions {
+		waveforms = append(waveforms, foldPeaks(mins, maxs, buckets))
+	}
+	return waveforms, nil
+}
+
+// foldPeaks reduces per-block peaks to the given number of buckets. Tracks
+// shorter than the resolution keep one bucket per block.
+func foldPeaks(mins, maxs []float32, buckets int) Waveform {
+	if buckets > len(mins) {
+		buckets = len(mins)
+	}
+
+	peaks := make([]int8, 0, buckets*2)
+	for bucket := 0; bucket < buckets; bucket++ {
+		start := bucket * len(mins) / buckets
+		end := (bucket + 1) * len(mins) / buckets
+
+		lo, hi := mins[start], maxs[start]
+		for i := start + 1; i < end; i++ {
+			lo = min(lo, mins[i])
+			hi = max(hi, maxs[i])
+		}
+		peaks = append(peaks, scalePeak(lo), scalePeak(hi))
+	}
+
+	return Waveform{Buckets: buckets, Peaks: peaks}
+}
+
+func scalePeak(v float32) int8 {
+	return int8(math.Round(float64(max(-1, min(1, v)) * 127)))
+}
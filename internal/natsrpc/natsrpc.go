@@ -0,0 +1,75 @@
+package natsrpc
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	AuthorizationHeader = "Authorization"
+	StatusHeader        = "Status-Code"
+)
+
+// Request sends data to subject, forwarding the caller's bearer token so the
+// responding service can make its own authorization decision.
+func Request(ctx context.Context, nc *nats.Conn, subject string, data []byte, bearer string, timeout time.Duration) (*nats.Msg, error) {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	if bearer != "" {
+		msg.Header.Set(AuthorizationHeader, "Bearer "+bearer)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	response, err := nc.RequestMsgWithContext(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// RespondError replies with a gRPC status code in the headers and the message as the body.
+func RespondError(m *nats.Msg, code codes.Code, message string) error {
+	response := nats.NewMsg(m.Reply)
+	response.Header.Set(StatusHeader, strconv.Itoa(int(code)))
+	response.Data = []byte(message)
+	return m.RespondMsg(response)
+}
+
+// ErrorFromResponse turns a RespondError reply back into a gRPC status error.
+func ErrorFromResponse(m *nats.Msg) error {
+	if m.Header == nil {
+		return nil
+	}
+
+	codeHeader := m.Header.Get(StatusHeader)
+	if codeHeader == "" {
+		return nil
+	}
+
+	code, err := strconv.Atoi(codeHeader)
+	if err != nil {
+		return status.Errorf(codes.Internal, "invalid status header %q", codeHeader)
+	}
+
+	return status.Error(codes.Code(code), string(m.Data))
+}
+
+// Authorization returns the Authorization header sent with a Request, or "" if none was attached.
+func Authorization(m *nats.Msg) string {
+	if m.Header == nil {
+		return ""
+	}
+	return m.Header.Get(AuthorizationHeader)
+}
@@ -0,0 +1,442 @@
+// Package reconcile cross-checks song documents, GridFS files and the blob
+// index, reporting and optionally repairing whatever a failed upload or delete
+// left behind.
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/storage"
+)
+
+// errBlobChanged reports a blob index entry that changed after it was scanned.
+var errBlobChanged = errors.New("blob index entry changed since the scan")
+
+// Options controls a reconciliation run.
+type Options struct {
+	// Repair deletes orphaned files and dangling songs and fixes the blob
+	// index. Without it the run only reports.
+	Repair bool
+	// GracePeriod protects files uploaded recently: their song may not have
+	// been written yet.
+	GracePeriod time.Duration
+}
+
+// DanglingSong is a song document pointing at a GridFS file that does not exist.
+type DanglingSong struct {
+	SongID primitive.ObjectID
+	Title  string
+	// MissingSongFile means the song is unplayable; otherwise only the cover
+	// is gone.
+	MissingSongFile bool
+	// AlbumCoverID is the song's existing cover, set for unplayable songs
+	// whose cover reference repair releases along with the song.
+	AlbumCoverID primitive.ObjectID
+}
+
+// OrphanedFile is a GridFS file no song or upload session uses. Blob is set
+// when the file is in the blob index, as it was when scanned.
+type OrphanedFile struct {
+	FileID primitive.ObjectID
+	Blob   *BlobEntry
+}
+
+// BlobEntry is a blob index entry.
+type BlobEntry struct {
+	SHA256 string             `bson:"_id"`
+	FileID primitive.ObjectID `bson:"fileId"`
+	Refs   int64              `bson:"refs"`
+}
+
+// BlobRefMismatch is a blob index entry whose reference count disagrees with
+// the songs and upload sessions that use the file.
+type BlobRefMismatch struct {
+	SHA256   string
+	FileID   primitive.ObjectID
+	Recorded int64
+	Actual   int64
+}
+
+// Report lists what a run found. Repaired and RepairFailures count repair
+// actions and stay zero unless Options.Repair is set.
+type Report struct {
+	ScannedFiles    int
+	ScannedSongs    int
+	OrphanedFiles   []OrphanedFile
+	DanglingSongs   []DanglingSong
+	StaleBlobs      []string
+	BlobRefMismatch []BlobRefMismatch
+	OrphanWaveforms []primitive.ObjectID
+	Repaired        int
+	RepairFailures  int
+}
+
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"scanned %d files and %d songs: %d orphaned files, %d dangling songs, %d stale blob entries, %d blob ref mismatches, %d orphaned waveforms; repaired %d, failed %d",
+		r.ScannedFiles, r.ScannedSongs, len(r.OrphanedFiles), len(r.DanglingSongs), len(r.StaleBlobs),
+		len(r.BlobRefMismatch), len(r.OrphanWaveforms), r.Repaired, r.RepairFailures,
+	)
+}
+
+type storedFile struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UploadDate  time.Time          `bson:"uploadDate"`
+	ThumbnailOf primitive.ObjectID `bson:"thumbnailOf"`
+}
+
+type songRefs struct {
+	ID           primitive.ObjectID `bson:"_id"`
+	Title        string             `bson:"title"`
+	SongFileID   string             `bson:"songFileID"`
+	AlbumCoverID string             `bson:"albumCoverID"`
+}
+
+// Run performs one reconciliation pass over db.
+func Run(ctx context.Context, db *mongo.Database, opts Options) (*Report, error) {
+	report := &Report{}
+
+	bucket, err := gridfs.NewBucket(db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GridFS bucket: %v", err)
+	}
+
+	files, err := loadFiles(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	report.ScannedFiles = len(files)
+
+	refs := make(map[primitive.ObjectID]int64)
+	if err := scanSongs(ctx, db, files, refs, report); err != nil {
+		return nil, err
+	}
+	if err := scanUploadSessions(ctx, db, refs); err != nil {
+		return nil, err
+	}
+
+	blobs, err := loadBlobs(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(map[primitive.ObjectID]*BlobEntry, len(blobs))
+	for i := range blobs {
+		indexed[blobs[i].FileID] = &blobs[i]
+	}
+
+	cutoff := time.Now().Add(-opts.GracePeriod)
+	for id, file := range files {
+		if file.UploadDate.After(cutoff) || refs[id] > 0 {
+			continue
+		}
+		if !file.ThumbnailOf.IsZero() {
+			// A thumbnail lives as long as its cover.
+			if parent, ok := files[file.ThumbnailOf]; ok && (refs[parent.ID] > 0 || parent.UploadDate.After(cutoff)) {
+				continue
+			}
+		}
+		report.OrphanedFiles = append(report.OrphanedFiles, OrphanedFile{FileID: id, Blob: indexed[id]})
+	}
+
+	scanBlobs(blobs, files, refs, cutoff, report)
+	if err := scanWaveforms(ctx, db, files, report); err != nil {
+		return nil, err
+	}
+
+	if opts.Repair {
+		repair(ctx, db, bucket, report)
+	}
+
+	return report, nil
+}
+
+func loadFiles(ctx context.Context, db *mongo.Database) (map[primitive.ObjectID]storedFile, error) {
+	cursor, err := db.Collection(storage.FilesCollection).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"uploadDate": 1, "thumbnailOf": "$metadata.thumbnailOf"}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GridFS files: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	files := make(map[primitive.ObjectID]storedFile)
+	for cursor.Next(ctx) {
+		var file storedFile
+		if err := cursor.Decode(&file); err != nil {
+			// Files not stored by our services may use non-ObjectID IDs.
+			continue
+		}
+		files[file.ID] = file
+	}
+	return files, cursor.Err()
+}
+
+func scanSongs(ctx context.Context, db *mongo.Database, files map[primitive.ObjectID]storedFile, refs map[primitive.ObjectID]int64, report *Report) error {
+	cursor, err := db.Collection(storage.SongsCollection).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"title": 1, "songFileID": 1, "albumCoverID": 1}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list songs: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var song songRefs
+		if err := cursor.Decode(&song); err != nil {
+			return fmt.Errorf("failed to decode song: %v", err)
+		}
+		report.ScannedSongs++
+
+		var coverID primitive.ObjectID
+		if song.AlbumCoverID != "" {
+			id, err := primitive.ObjectIDFromHex(song.AlbumCoverID)
+			if _, ok := files[id]; err == nil && ok {
+				coverID = id
+			}
+		}
+
+		songFileID, err := primitive.ObjectIDFromHex(song.SongFileID)
+		if _, ok := files[songFileID]; err != nil || !ok {
+			// The song still holds its cover until repair deletes it.
+			if !coverID.IsZero() {
+				refs[coverID]++
+			}
+			report.DanglingSongs = append(report.DanglingSongs, DanglingSong{
+				SongID:          song.ID,
+				Title:           song.Title,
+				MissingSongFile: true,
+				AlbumCoverID:    coverID,
+			})
+			continue
+		}
+		refs[songFileID]++
+
+		if song.AlbumCoverID == "" {
+			continue
+		}
+		if coverID.IsZero() {
+			report.DanglingSongs = append(report.DanglingSongs, DanglingSong{SongID: song.ID, Title: song.Title})
+			continue
+		}
+		refs[coverID]++
+	}
+	return cursor.Err()
+}
+
+// scanUploadSessions counts the covers sessions hold and the song files of
+// finalized sessions whose song has not been written yet.
+func scanUploadSessions(ctx context.Context, db *mongo.Database, refs map[primitive.ObjectID]int64) error {
+	cursor, err := db.Collection(storage.UploadSessionsCollection).Find(ctx, bson.M{},
+		options.Find().SetProjection(bson.M{"albumCoverID": 1, "pendingSongFileId": 1}),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list upload sessions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var session struct {
+			AlbumCoverID      primitive.ObjectID `bson:"albumCoverID"`
+			PendingSongFileID string             `bson:"pendingSongFileId"`
+		}
+		if err := cursor.Decode(&session); err != nil {
+			return fmt.Errorf("failed to decode upload session: %v", err)
+		}
+		if !session.AlbumCoverID.IsZero() {
+			refs[session.AlbumCoverID]++
+		}
+		if pendingID, err := primitive.ObjectIDFromHex(session.PendingSongFileID); err == nil {
+			refs[pendingID]++
+		}
+	}
+	return cursor.Err()
+}
+
+func loadBlobs(ctx context.Context, db *mongo.Database) ([]BlobEntry, error) {
+	cursor, err := db.Collection(storage.BlobsCollection).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list blobs: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	var blobs []BlobEntry
+	for cursor.Next(ctx) {
+		var blob BlobEntry
+		if err := cursor.Decode(&blob); err != nil {
+			return nil, fmt.Errorf("failed to decode blob: %v", err)
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, cursor.Err()
+}
+
+// scanBlobs leaves out files uploaded after cutoff: their references may be
+// taken before the song that holds them is written.
+func scanBlobs(blobs []BlobEntry, files map[primitive.ObjectID]storedFile, refs map[primitive.ObjectID]int64, cutoff time.Time, report *Report) {
+	for _, blob := range blobs {
+		file, ok := files[blob.FileID]
+		if !ok {
+			report.StaleBlobs = append(report.StaleBlobs, blob.SHA256)
+			continue
+		}
+		if file.UploadDate.After(cutoff) {
+			continue
+		}
+		if actual := refs[blob.FileID]; actual != blob.Refs {
+			report.BlobRefMismatch = append(report.BlobRefMismatch, BlobRefMismatch{
+				SHA256:   blob.SHA256,
+				FileID:   blob.FileID,
+				Recorded: blob.Refs,
+				Actual:   actual,
+			})
+		}
+	}
+}
+
+func scanWaveforms(ctx context.Context, db *mongo.Database, files map[primitive.ObjectID]storedFile, report *Report) error {
+	cursor, err := db.Collection(storage.WaveformsCollection).Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return fmt.Errorf("failed to list waveforms: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var waveform struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&waveform); err != nil {
+			return fmt.Errorf("failed to decode waveform: %v", err)
+		}
+		if _, ok := files[waveform.ID]; !ok {
+			report.OrphanWaveforms = append(report.OrphanWaveforms, waveform.ID)
+		}
+	}
+	return cursor.Err()
+}
+
+func repair(ctx context.Context, db *mongo.Database, bucket *gridfs.Bucket, report *Report) {
+	count := func(err error) {
+		if err != nil {
+			report.RepairFailures++
+			return
+		}
+		report.Repaired++
+	}
+
+	// Orphans are deleted as individual files: their thumbnails are listed
+	// separately when orphaned too. An indexed file loses its index entry
+	// first, and only if nobody acquired it since the scan.
+	orphaned := make(map[primitive.ObjectID]bool, len(report.OrphanedFiles))
+	for _, orphan := range report.OrphanedFiles {
+		orphaned[orphan.FileID] = true
+		if orphan.Blob != nil {
+			result, err := db.Collection(storage.BlobsCollection).DeleteOne(ctx, bson.M{
+				"_id":    orphan.Blob.SHA256,
+				"fileId": orphan.FileID,
+				"refs":   orphan.Blob.Refs,
+			})
+			if err == nil && result.DeletedCount == 0 {
+				err = errBlobChanged
+			}
+			if err != nil {
+				count(err)
+				continue
+			}
+		}
+
+		err := bucket.DeleteContext(ctx, orphan.FileID)
+		if err == nil || err == gridfs.ErrFileNotFound {
+			_, err = db.Collection(storage.WaveformsCollection).DeleteOne(ctx, bson.M{"_id": orphan.FileID})
+		}
+		count(err)
+	}
+
+	// Deleting an unplayable song releases its cover; the cover's blob entry
+	// then no longer matches the scan and is left alone below.
+	released := make(map[primitive.ObjectID]bool)
+	songs := db.Collection(storage.SongsCollection)
+	for _, song := range report.DanglingSongs {
+		var err error
+		if song.MissingSongFile {
+			var result *mongo.DeleteResult
+			result, err = songs.DeleteOne(ctx, bson.M{"_id": song.SongID})
+			if err == nil && result.DeletedCount == 1 && !song.AlbumCoverID.IsZero() {
+				released[song.AlbumCoverID] = true
+				err = releaseBlob(ctx, db, bucket, song.AlbumCoverID)
+			}
+		} else {
+			_, err = songs.UpdateOne(ctx, bson.M{"_id": song.SongID}, bson.M{
+				"$set":   bson.M{"albumCoverID": ""},
+				"$unset": bson.M{"albumCoverSize": "", "albumCoverMimeType": "", "albumCoverSha256": ""},
+			})
+		}
+		count(err)
+	}
+
+	blobs := db.Collection(storage.BlobsCollection)
+	for _, sha := range report.StaleBlobs {
+		_, err := blobs.DeleteOne(ctx, bson.M{"_id": sha})
+		count(err)
+	}
+	for _, mismatch := range report.BlobRefMismatch {
+		if orphaned[mismatch.FileID] || released[mismatch.FileID] {
+			// Repaired together with the orphaned file or the deleted song.
+			continue
+		}
+		_, err := blobs.UpdateOne(ctx,
+			bson.M{"_id": mismatch.SHA256, "refs": mismatch.Recorded},
+			bson.M{"$set": bson.M{"refs": mismatch.Actual}},
+		)
+		count(err)
+	}
+
+	for _, id := range report.OrphanWaveforms {
+		_, err := db.Collection(storage.WaveformsCollection).DeleteOne(ctx, bson.M{"_id": id})
+		count(err)
+	}
+}
+
+// releaseBlob drops the reference a deleted song held on fileID, deleting the
+// file once nothing refers to it, as the upload services do. Files without a
+// blob entry are left for the orphan scan of a later run.
+func releaseBlob(ctx context.Context, db *mongo.Database, bucket *gridfs.Bucket, fileID primitive.ObjectID) error {
+	blobs := db.Collection(storage.BlobsCollection)
+
+	var blob BlobEntry
+	err := blobs.FindOneAndUpdate(ctx,
+		bson.M{"fileId": fileID},
+		bson.M{"$inc": bson.M{"refs": -1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&blob)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil
+	}
+	if err != nil || blob.Refs > 0 {
+		return err
+	}
+
+	// Only delete if no upload took a new reference in the meantime.
+	deleted, err := blobs.DeleteOne(ctx, bson.M{"_id": blob.SHA256, "fileId": fileID, "refs": bson.M{"$lte": 0}})
+	if err != nil || deleted.DeletedCount == 0 {
+		return err
+	}
+	if err := storage.DeleteThumbnails(ctx, bucket, fileID); err != nil {
+		return err
+	}
+	if _, err := db.Collection(storage.WaveformsCollection).DeleteOne(ctx, bson.M{"_id": fileID}); err != nil {
+		return err
+	}
+	if err := bucket.DeleteContext(ctx, fileID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+		return err
+	}
+	return nil
+}
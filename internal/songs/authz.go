@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/songs/repository"
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+	"github.com/maksymshtarkberg/music-player-go/pkg/token"
+)
+
+// authenticate verifies the bearer token forwarded with a NATS request.
+func authenticate(m *nats.Msg, secret []byte) (*token.Claims, error) {
+	tokenString, err := token.FromAuthorizationHeader(natsrpc.Authorization(m))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	claims, err := token.Verify(secret, tokenString)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return claims, nil
+}
+
+// authorizeSongMutation allows only the uploader of a song or an admin to change it.
+func authorizeSongMutation(ctx context.Context, songs repository.SongRepository, songID primitive.ObjectID, claims *token.Claims) error {
+	song, err := songs.Get(ctx, songID)
+	if err != nil {
+		return repositoryError(err)
+	}
+
+	if song.UploadedBy != claims.UserID && !claims.HasRole(models.RoleAdmin) {
+		return status.Error(codes.PermissionDenied, "only the uploader or an admin can modify this song")
+	}
+
+	return nil
+}
+
+func respondStatus(m *nats.Msg, err error) {
+	st, _ := status.FromError(err)
+	natsrpc.RespondError(m, st.Code(), st.Message())
+}
@@ -2,127 +2,219 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"slices"
 	"time"
 
 	"log"
 
 	"github.com/nats-io/nats.go"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/nats-io/nats.go/jetstream"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-
-	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/ingest"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/songs/repository"
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
-func HandleUploadSong(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
-	return func(m *nats.Msg) {
+func songToProto(song *models.Song) *pb.SongMetadata {
+	return &pb.SongMetadata{
+		XId:                song.ID.Hex(),
+		Title:              song.Title,
+		Artist:             song.Artist,
+		Album:              song.Album,
+		Description:        song.Description,
+		UploadedBy:         song.UploadedBy,
+		SongFileID:         song.SongFileID,
+		AlbumCoverID:       song.AlbumCoverID,
+		UploadedAt:         int64(song.UploadedAt),
+		SongFileSize:       song.SongFileSize,
+		SongMimeType:       song.SongMimeType,
+		SongSha256:         song.SongSHA256,
+		AlbumCoverSize:     song.CoverSize,
+		AlbumCoverMimeType: song.CoverMimeType,
+		AlbumCoverSha256:   song.CoverSHA256,
+		TrackNumber:        song.TrackNumber,
+		Year:               song.Year,
+		Genre:              song.Genre,
+		DurationMs:         song.DurationMs,
+		SampleRate:         song.SampleRate,
+		Channels:           song.Channels,
+		Bitrate:            song.Bitrate,
+		DeletedAt:          int64(song.DeletedAt),
+		Version:            song.Version,
+	}
+}
+
+func songsToProto(songs []*models.Song) []*pb.SongMetadata {
+	result := make([]*pb.SongMetadata, 0, len(songs))
+	for _, song := range songs {
+		result = append(result, songToProto(song))
+	}
+	return result
+}
+
+func listOptions(opts *pb.SongListOptions) repository.ListOptions {
+	sortBy := repository.SortByID
+	switch opts.GetSortBy() {
+	case pb.SongSortField_SONG_SORT_FIELD_TITLE:
+		sortBy = repository.SortByTitle
+	case pb.SongSortField_SONG_SORT_FIELD_ARTIST:
+		sortBy = repository.SortByArtist
+	case pb.SongSortField_SONG_SORT_FIELD_UPLOADED_AT:
+		sortBy = repository.SortByUploadedAt
+	}
+
+	return repository.ListOptions{
+		Filter: repository.SongFilter{
+			UploadedBy: opts.GetUploadedBy(),
+			Artist:     opts.GetArtist(),
+			Album:      opts.GetAlbum(),
+		},
+		SortBy:     sortBy,
+		Descending: opts.GetDescending(),
+		PageSize:   int(opts.GetPageSize()),
+		PageToken:  opts.GetPageToken(),
+	}
+}
+
+// repositoryError maps repository errors onto gRPC status codes.
+func repositoryError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, "No song found with the specified ID")
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, "A song with the specified ID already exists")
+	case errors.Is(err, repository.ErrVersionConflict):
+		return status.Error(codes.Aborted, "Song was modified by another request; reload it and try again")
+	case errors.Is(err, repository.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, "Invalid page token")
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// HandleUploadSong writes songs delivered from the ingest stream. Messages are
+// acknowledged only once written; failed writes are retried with backoff and
+// malformed ones are dead-lettered straight away.
+func HandleUploadSong(nc *nats.Conn, js jetstream.JetStream, songs repository.SongRepository) jetstream.MessageHandler {
+	return func(m jetstream.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
 		var metadata pb.SongMetadata
-		err := proto.Unmarshal(m.Data, &metadata)
+		err := proto.Unmarshal(m.Data(), &metadata)
 		if err != nil {
 			log.Printf("Failed to unmarshal song metadata: %v", err)
+			if err := ingest.DeadLetter(ctx, js, nc, m, codes.InvalidArgument, "invalid song metadata"); err != nil {
+				log.Printf("Failed to dead-letter song metadata: %v", err)
+			}
 			return
 		}
 
-		songDoc := bson.M{
-			"title":        metadata.Title,
-			"artist":       metadata.Artist,
-			"album":        metadata.Album,
-			"description":  metadata.Description,
-			"uploadedBy":   metadata.UploadedBy,
-			"songFileID":   metadata.SongFileID,
-			"albumCoverID": metadata.AlbumCoverID,
+		song := &models.Song{
+			Title:         metadata.Title,
+			Artist:        metadata.Artist,
+			Album:         metadata.Album,
+			Description:   metadata.Description,
+			UploadedBy:    metadata.UploadedBy,
+			SongFileID:    metadata.SongFileID,
+			AlbumCoverID:  metadata.AlbumCoverID,
+			UploadedAt:    primitive.DateTime(metadata.UploadedAt),
+			SongFileSize:  metadata.SongFileSize,
+			SongMimeType:  metadata.SongMimeType,
+			SongSHA256:    metadata.SongSha256,
+			CoverSize:     metadata.AlbumCoverSize,
+			CoverMimeType: metadata.AlbumCoverMimeType,
+			CoverSHA256:   metadata.AlbumCoverSha256,
+			TrackNumber:   metadata.TrackNumber,
+			Year:          metadata.Year,
+			Genre:         metadata.Genre,
+			DurationMs:    metadata.DurationMs,
+			SampleRate:    metadata.SampleRate,
+			Channels:      metadata.Channels,
+			Bitrate:       metadata.Bitrate,
+		}
+		if song.UploadedAt == 0 {
+			song.UploadedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+		// The ID assigned by the uploader makes redeliveries idempotent.
+		if id, err := primitive.ObjectIDFromHex(metadata.XId); err == nil {
+			song.ID = id
 		}
 
-		collection := db.Collection("songs")
-		_, err = collection.InsertOne(context.TODO(), songDoc)
+		err = songs.Create(ctx, song)
+		if errors.Is(err, repository.ErrAlreadyExists) && !song.ID.IsZero() {
+			log.Printf("Song metadata for %s was already saved", song.ID.Hex())
+			err = nil
+		}
 		if err != nil {
 			log.Printf("Failed to save song metadata: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+			if err := ingest.Retry(ctx, js, nc, m, err.Error()); err != nil {
+				log.Printf("Failed to schedule song metadata for retry: %v", err)
+			}
 			return
 		}
 
-		log.Printf("Song metadata for %s by %s saved successfully", metadata.Title, metadata.Artist)
+		if err := m.Ack(); err != nil {
+			log.Printf("Failed to acknowledge song metadata: %v", err)
+		}
+		if err := ingest.Confirm(nc, m, codes.OK, ""); err != nil {
+			log.Printf("Failed to confirm song metadata: %v", err)
+		}
 
+		log.Printf("Song metadata for %s by %s saved successfully", metadata.Title, metadata.Artist)
 	}
 }
 
-func HandleGetAllSongs(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
+// HandleMaxDeliveries dead-letters uploads whose last delivery was never
+// acknowledged, which HandleUploadSong cannot do itself.
+func HandleMaxDeliveries(nc *nats.Conn, js jetstream.JetStream) func(m *nats.Msg) {
 	return func(m *nats.Msg) {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		log.Println("Attempting to send all songs")
 
-		collection := db.Collection("songs")
-		cursor, err := collection.Find(ctx, bson.M{})
-		if err != nil {
-			log.Printf("Failed to retrieve songs: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
-			return
+		if err := ingest.DeadLetterExhausted(ctx, js, nc, m.Data); err != nil {
+			log.Printf("Failed to dead-letter undelivered song metadata: %v", err)
 		}
-		defer cursor.Close(ctx)
-
-		var songs []*pb.SongMetadata
-		for cursor.Next(ctx) {
-			var songDoc bson.M
-			if err := cursor.Decode(&songDoc); err != nil {
-				log.Printf("Failed to decode song document: %v", err)
-				m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
-				return
-			}
-			songId, ok := songDoc["_id"].(primitive.ObjectID)
-			if !ok {
-				log.Printf("Failed to cast _id to ObjectID")
-				m.Respond([]byte("Error: Failed to cast _id to ObjectID"))
-				return
-			}
+	}
+}
 
-			song := &pb.SongMetadata{
-				XId:          songId.Hex(),
-				Title:        songDoc["title"].(string),
-				Artist:       songDoc["artist"].(string),
-				Album:        songDoc["album"].(string),
-				Description:  songDoc["description"].(string),
-				UploadedBy:   songDoc["uploadedBy"].(string),
-				SongFileID:   songDoc["songFileID"].(string),
-				AlbumCoverID: songDoc["albumCoverID"].(string),
-			}
-			songs = append(songs, song)
-		}
+func HandleGetAllSongs(nc *nats.Conn, songs repository.SongRepository) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		log.Println("Attempting to send all songs")
 
-		if err := cursor.Err(); err != nil {
-			log.Printf("Error while iterating songs cursor: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+		var req pb.GetAllSongsRequest
+		if err := proto.Unmarshal(m.Data, &req); err != nil {
+			natsrpc.RespondError(m, codes.InvalidArgument, "Failed to unmarshal request")
 			return
 		}
 
-		if len(songs) == 0 {
-			log.Println("No songs found in the database")
-			response := &pb.GetAllSongsResponse{
-				Songs: []*pb.SongMetadata{},
-			}
-
-			responseData, err := proto.Marshal(response)
-			if err != nil {
-				log.Printf("Failed to marshal response: %v", err)
-				m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
-				return
-			}
-
-			m.Respond(responseData)
+		page, err := songs.List(ctx, listOptions(req.GetOptions()))
+		if err != nil {
+			log.Printf("Failed to retrieve songs: %v", err)
+			respondStatus(m, repositoryError(err))
 			return
 		}
 
 		response := &pb.GetAllSongsResponse{
-			Songs: songs,
+			Songs:         songsToProto(page.Songs),
+			NextPageToken: page.NextPageToken,
+			TotalCount:    page.TotalCount,
 		}
 
 		responseData, err := proto.Marshal(response)
 		if err != nil {
 			log.Printf("Failed to marshal response: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+			natsrpc.RespondError(m, codes.Internal, err.Error())
 			return
 		}
 
@@ -130,83 +222,73 @@ func HandleGetAllSongs(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
 	}
 }
 
-func HandleGetUserSongs(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
+func HandleGetUserSongs(nc *nats.Conn, songs repository.SongRepository) func(m *nats.Msg) {
 	return func(m *nats.Msg) {
-		userId := string(m.Data)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
+
+		var req pb.GetUserSongsRequest
+		if err := proto.Unmarshal(m.Data, &req); err != nil {
+			natsrpc.RespondError(m, codes.InvalidArgument, "Failed to unmarshal request")
+			return
+		}
+		userId := req.GetUserId()
 		log.Printf("Attempting to stream songs for user ID: %s", userId)
 
-		collection := db.Collection("songs")
-		filter := bson.M{"uploadedBy": userId}
-		cursor, err := collection.Find(ctx, filter)
+		opts := listOptions(req.GetOptions())
+		opts.Filter.UploadedBy = userId
+
+		page, err := songs.List(ctx, opts)
 		if err != nil {
 			log.Printf("Failed to find songs for user %s: %v", userId, err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+			respondStatus(m, repositoryError(err))
 			return
 		}
-		defer cursor.Close(ctx)
-
-		var songs []*pb.SongMetadata
-		for cursor.Next(ctx) {
-			var songDoc bson.M
-			if err := cursor.Decode(&songDoc); err != nil {
-				log.Printf("Failed to decode song document: %v", err)
-				m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
-				return
-			}
 
-			songId, ok := songDoc["_id"].(primitive.ObjectID)
-			if !ok {
-				log.Printf("Failed to cast _id to ObjectID")
-				m.Respond([]byte("Error: Failed to cast _id to ObjectID"))
-				return
-			}
-
-			song := &pb.SongMetadata{
-				XId:          songId.Hex(),
-				Title:        songDoc["title"].(string),
-				Artist:       songDoc["artist"].(string),
-				Album:        songDoc["album"].(string),
-				Description:  songDoc["description"].(string),
-				UploadedBy:   songDoc["uploadedBy"].(string),
-				SongFileID:   songDoc["songFileID"].(string),
-				AlbumCoverID: songDoc["albumCoverID"].(string),
-			}
-			songs = append(songs, song)
+		response := &pb.GetUserSongsResponse{
+			Songs:         songsToProto(page.Songs),
+			NextPageToken: page.NextPageToken,
+			TotalCount:    page.TotalCount,
 		}
 
-		if err := cursor.Err(); err != nil {
-			log.Printf("Error while iterating songs cursor: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+		responseData, err := proto.Marshal(response)
+		if err != nil {
+			log.Printf("Failed to marshal response: %v", err)
+			natsrpc.RespondError(m, codes.Internal, err.Error())
 			return
 		}
 
-		if len(songs) == 0 {
-			log.Printf("No songs found for user %s", userId)
-			response := &pb.GetUserSongsResponse{
-				Songs: []*pb.SongMetadata{},
-			}
+		m.Respond(responseData)
+	}
+}
 
-			responseData, err := proto.Marshal(response)
-			if err != nil {
-				log.Printf("Failed to marshal response: %v", err)
-				m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
-				return
-			}
+func HandleGetSong(nc *nats.Conn, songs repository.SongRepository) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-			m.Respond(responseData)
+		songIdStr := string(m.Data)
+		objectID, err := primitive.ObjectIDFromHex(songIdStr)
+		if err != nil {
+			natsrpc.RespondError(m, codes.InvalidArgument, "Invalid song ID")
 			return
 		}
 
-		response := &pb.GetUserSongsResponse{
-			Songs: songs,
+		song, err := songs.Get(ctx, objectID)
+		if err == nil && song.DeletedAt != 0 {
+			// Trashed songs are only listed through the trash.
+			err = repository.ErrNotFound
+		}
+		if err != nil {
+			log.Printf("Failed to retrieve song %s: %v", songIdStr, err)
+			respondStatus(m, repositoryError(err))
+			return
 		}
 
-		responseData, err := proto.Marshal(response)
+		responseData, err := proto.Marshal(songToProto(song))
 		if err != nil {
 			log.Printf("Failed to marshal response: %v", err)
-			m.Respond([]byte(fmt.Sprintf("Error: %v", err)))
+			natsrpc.RespondError(m, codes.Internal, err.Error())
 			return
 		}
 
@@ -214,13 +296,22 @@ func HandleGetUserSongs(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
 	}
 }
 
-func HandleUpdateSongMetadata(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
+func HandleUpdateSongMetadata(nc *nats.Conn, songs repository.SongRepository, secret []byte) func(m *nats.Msg) {
 	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		claims, err := authenticate(m, secret)
+		if err != nil {
+			respondStatus(m, err)
+			return
+		}
+
 		var req pb.UpdateSongMetadataRequest
-		err := proto.Unmarshal(m.Data, &req)
+		err = proto.Unmarshal(m.Data, &req)
 		if err != nil {
 			log.Printf("Failed to unmarshal request: %v", err)
-			m.Respond([]byte("Error: Failed to unmarshal request"))
+			natsrpc.RespondError(m, codes.InvalidArgument, "Failed to unmarshal request")
 			return
 		}
 
@@ -228,37 +319,46 @@ func HandleUpdateSongMetadata(nc *nats.Conn, db *mongo.Database) func(m *nats.Ms
 		objectID, err := primitive.ObjectIDFromHex(songIdStr)
 		if err != nil {
 			log.Printf("Failed to convert song ID to ObjectID: %v", err)
-			m.Respond([]byte("Error: Invalid song ID"))
+			natsrpc.RespondError(m, codes.InvalidArgument, "Invalid song ID")
+			return
+		}
+
+		fields, err := updateMaskFields(req.GetUpdateMask())
+		if err != nil {
+			respondStatus(m, err)
 			return
 		}
 
-		collection := db.Collection("songs")
-		filter := bson.M{"_id": objectID}
-		update := bson.M{
-			"$set": bson.M{
-				"title":       req.GetTitle(),
-				"artist":      req.GetArtist(),
-				"album":       req.GetAlbum(),
-				"description": req.GetDescription(),
-			},
+		if err := authorizeSongMutation(ctx, songs, objectID, claims); err != nil {
+			log.Printf("User %s may not update song %s: %v", claims.UserID, songIdStr, err)
+			respondStatus(m, err)
+			return
 		}
 
-		_, err = collection.UpdateOne(context.TODO(), filter, update)
+		song, err := songs.Update(ctx, objectID, repository.SongUpdate{
+			Title:       req.GetTitle(),
+			Artist:      req.GetArtist(),
+			Album:       req.GetAlbum(),
+			Description: req.GetDescription(),
+			Fields:      fields,
+			Version:     req.GetVersion(),
+		})
 		if err != nil {
 			log.Printf("Failed to update song metadata: %v", err)
-			m.Respond([]byte("Error: Failed to update song metadata"))
+			respondStatus(m, repositoryError(err))
 			return
 		}
 
 		response := &pb.UpdateSongMetadataResponse{
 			Success: true,
 			Message: "Song metadata updated successfully",
+			Version: song.Version,
 		}
 
 		responseData, err := proto.Marshal(response)
 		if err != nil {
 			log.Printf("Failed to marshal response: %v", err)
-			m.Respond([]byte("Error: Failed to marshal response"))
+			natsrpc.RespondError(m, codes.Internal, "Failed to marshal response")
 			return
 		}
 
@@ -266,45 +366,172 @@ func HandleUpdateSongMetadata(nc *nats.Conn, db *mongo.Database) func(m *nats.Ms
 	}
 }
 
-func HandleDeleteSong(nc *nats.Conn, db *mongo.Database) func(m *nats.Msg) {
+// HandleDeleteSong moves a song to the trash; HandlePurgeSong deletes it for good.
+func HandleDeleteSong(nc *nats.Conn, songs repository.SongRepository, secret []byte) func(m *nats.Msg) {
 	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
 
-		songIdStr := string(m.Data)
-		objectID, err := primitive.ObjectIDFromHex(songIdStr)
-		if err != nil {
-			log.Printf("Failed to convert song ID to ObjectID: %v", err)
-			m.Respond([]byte("Error: Invalid song ID"))
+		objectID, ok := authorizedSongMutation(ctx, m, songs, secret)
+		if !ok {
 			return
 		}
 
-		collection := db.Collection("songs")
-		filter := bson.M{"_id": objectID}
+		if err := songs.Trash(ctx, objectID, time.Now()); err != nil {
+			log.Printf("Failed to trash song %s: %v", objectID.Hex(), err)
+			respondStatus(m, repositoryError(err))
+			return
+		}
 
-		result, err := collection.DeleteOne(context.TODO(), filter)
-		if err != nil {
-			log.Printf("Failed to delete song: %v", err)
-			m.Respond([]byte("Error: Failed to delete song"))
+		respondProto(m, &pb.DeleteSongResponse{
+			Success: true,
+			Message: "Song moved to trash",
+		})
+	}
+}
+
+func HandleRestoreSong(nc *nats.Conn, songs repository.SongRepository, secret []byte) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		objectID, ok := authorizedSongMutation(ctx, m, songs, secret)
+		if !ok {
 			return
 		}
 
-		if result.DeletedCount == 0 {
-			log.Printf("No song found with ID %s", songIdStr)
-			m.Respond([]byte("Error: No song found with the specified ID"))
+		if err := songs.Restore(ctx, objectID); err != nil {
+			log.Printf("Failed to restore song %s: %v", objectID.Hex(), err)
+			respondStatus(m, trashError(err))
 			return
 		}
 
-		response := &pb.DeleteSongResponse{
+		respondProto(m, &pb.RestoreSongResponse{
 			Success: true,
-			Message: "Song deleted successfully",
+			Message: "Song restored successfully",
+		})
+	}
+}
+
+// HandlePurgeSong permanently deletes the document of a trashed song. Its
+// GridFS files are released by the gRPC server that sent the request.
+func HandlePurgeSong(nc *nats.Conn, songs repository.SongRepository, secret []byte) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		objectID, ok := authorizedSongMutation(ctx, m, songs, secret)
+		if !ok {
+			return
 		}
 
-		responseData, err := proto.Marshal(response)
+		if err := songs.Purge(ctx, objectID); err != nil {
+			log.Printf("Failed to purge song %s: %v", objectID.Hex(), err)
+			respondStatus(m, trashError(err))
+			return
+		}
+
+		respondProto(m, &pb.PurgeSongResponse{
+			Success: true,
+			Message: "Song deleted permanently",
+		})
+	}
+}
+
+func HandleListTrash(nc *nats.Conn, songs repository.SongRepository, secret []byte) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		claims, err := authenticate(m, secret)
 		if err != nil {
-			log.Printf("Failed to marshal response: %v", err)
-			m.Respond([]byte("Error: Failed to marshal response"))
+			respondStatus(m, err)
 			return
 		}
 
-		m.Respond(responseData)
+		var req pb.ListTrashRequest
+		if err := proto.Unmarshal(m.Data, &req); err != nil {
+			natsrpc.RespondError(m, codes.InvalidArgument, "Failed to unmarshal request")
+			return
+		}
+
+		opts := listOptions(req.GetOptions())
+		opts.Filter.UploadedBy = claims.UserID
+		opts.Filter.Trashed = true
+
+		page, err := songs.List(ctx, opts)
+		if err != nil {
+			log.Printf("Failed to list trash of user %s: %v", claims.UserID, err)
+			respondStatus(m, repositoryError(err))
+			return
+		}
+
+		respondProto(m, &pb.ListTrashResponse{
+			Songs:         songsToProto(page.Songs),
+			NextPageToken: page.NextPageToken,
+			TotalCount:    page.TotalCount,
+		})
 	}
 }
+
+// updateMaskFields checks that mask only names updatable fields and returns
+// them. A nil or empty mask stands for all of them, as clients written before
+// masks existed send every field without one.
+func updateMaskFields(mask *fieldmaskpb.FieldMask) ([]string, error) {
+	paths := mask.GetPaths()
+	if len(paths) == 0 {
+		return slices.Clone(repository.UpdatableFields), nil
+	}
+	for _, path := range paths {
+		if !slices.Contains(repository.UpdatableFields, path) {
+			return nil, status.Errorf(codes.InvalidArgument, "Field %q cannot be updated", path)
+		}
+	}
+	return paths, nil
+}
+
+// authorizedSongMutation reads the song ID from m and checks that the caller
+// may change that song. If not, it has already responded and returns false.
+func authorizedSongMutation(ctx context.Context, m *nats.Msg, songs repository.SongRepository, secret []byte) (primitive.ObjectID, bool) {
+	claims, err := authenticate(m, secret)
+	if err != nil {
+		respondStatus(m, err)
+		return primitive.NilObjectID, false
+	}
+
+	songIdStr := string(m.Data)
+	objectID, err := primitive.ObjectIDFromHex(songIdStr)
+	if err != nil {
+		log.Printf("Failed to convert song ID to ObjectID: %v", err)
+		natsrpc.RespondError(m, codes.InvalidArgument, "Invalid song ID")
+		return primitive.NilObjectID, false
+	}
+
+	if err := authorizeSongMutation(ctx, songs, objectID, claims); err != nil {
+		log.Printf("User %s may not modify song %s: %v", claims.UserID, songIdStr, err)
+		respondStatus(m, err)
+		return primitive.NilObjectID, false
+	}
+
+	return objectID, true
+}
+
+// trashError reports a song that exists but is not in the trash as
+// FailedPrecondition rather than NotFound.
+func trashError(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return status.Error(codes.FailedPrecondition, "Song is not in the trash")
+	}
+	return repositoryError(err)
+}
+
+func respondProto(m *nats.Msg, response proto.Message) {
+	responseData, err := proto.Marshal(response)
+	if err != nil {
+		log.Printf("Failed to marshal response: %v", err)
+		natsrpc.RespondError(m, codes.Internal, "Failed to marshal response")
+		return
+	}
+
+	m.Respond(responseData)
+}
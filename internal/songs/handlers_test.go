@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/songs/repository"
+)
+
+func TestUpdateMaskFields(t *testing.T) {
+	cases := []struct {
+		name    string
+		mask    *fieldmaskpb.FieldMask
+		want    []string
+		wantErr bool
+	}{
+		{"absent mask", nil, repository.UpdatableFields, false},
+		{"empty mask", &fieldmaskpb.FieldMask{}, repository.UpdatableFields, false},
+		{"some fields", &fieldmaskpb.FieldMask{Paths: []string{"title", "album"}}, []string{"title", "album"}, false},
+		{"unknown field", &fieldmaskpb.FieldMask{Paths: []string{"title", "uploadedBy"}}, nil, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := updateMaskFields(c.mask)
+			if c.wantErr {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("updateMaskFields error = %v, want %v", err, codes.InvalidArgument)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("updateMaskFields failed: %v", err)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("updateMaskFields = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
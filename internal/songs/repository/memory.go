@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+// MemorySongRepository keeps songs in a map. It is meant for tests and local
+// experiments; search only does prefix matching on searchTerms.
+type MemorySongRepository struct {
+	mu    sync.RWMutex
+	songs map[primitive.ObjectID]models.Song
+}
+
+func NewMemorySongRepository() *MemorySongRepository {
+	return &MemorySongRepository{songs: map[primitive.ObjectID]models.Song{}}
+}
+
+func (r *MemorySongRepository) Create(ctx context.Context, song *models.Song) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if song.ID.IsZero() {
+		song.ID = primitive.NewObjectID()
+	}
+	if _, ok := r.songs[song.ID]; ok {
+		return ErrAlreadyExists
+	}
+	song.SearchTerms = SearchTerms(song.Title, song.Artist, song.Album)
+	song.Version = 1
+	r.songs[song.ID] = *song
+	return nil
+}
+
+func (r *MemorySongRepository) Get(ctx context.Context, id primitive.ObjectID) (*models.Song, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	song, ok := r.songs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &song, nil
+}
+
+func (r *MemorySongRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	opts = opts.normalized()
+	token, err := opts.decodeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	var matched []*models.Song
+	for _, song := range r.songs {
+		song := song
+		if matchesFilter(&song, opts.Filter) {
+			matched = append(matched, &song)
+		}
+	}
+	r.mu.RUnlock()
+
+	less := func(a, b *models.Song) bool {
+		if c := compareValues(sortValue(a, opts.SortBy), sortValue(b, opts.SortBy)); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(a.ID[:], b.ID[:]) < 0
+	}
+	if opts.Descending {
+		ascending := less
+		less = func(a, b *models.Song) bool { return ascending(b, a) }
+	}
+	sort.Slice(matched, func(i, j int) bool { return less(matched[i], matched[j]) })
+
+	songs := []*models.Song{}
+	for _, song := range matched {
+		if token != nil && !afterToken(song, token, opts) {
+			continue
+		}
+		songs = append(songs, song)
+		if len(songs) > opts.PageSize {
+			break
+		}
+	}
+
+	return newPage(songs, opts, int64(len(matched)))
+}
+
+func (r *MemorySongRepository) SearchCandidates(ctx context.Context, words, prefixes []string, limit int) ([]Candidate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var candidates []Candidate
+	for _, song := range r.songs {
+		song := song
+		if song.DeletedAt != 0 {
+			continue
+		}
+		if hasTermWithPrefix(song.SearchTerms, append(append([]string{}, words...), prefixes...)) {
+			candidates = append(candidates, Candidate{Song: &song})
+			if len(candidates) == limit {
+				break
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func (r *MemorySongRepository) Update(ctx context.Context, id primitive.ObjectID, update SongUpdate) (*models.Song, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, ok := r.songs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if update.Version != 0 && update.Version != song.Version {
+		return nil, ErrVersionConflict
+	}
+
+	update.apply(&song)
+	song.Version++
+	r.songs[id] = song
+	return &song, nil
+}
+
+func (r *MemorySongRepository) Trash(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, ok := r.songs[id]
+	if !ok || song.DeletedAt != 0 {
+		return ErrNotFound
+	}
+	song.DeletedAt = primitive.NewDateTimeFromTime(at)
+	r.songs[id] = song
+	return nil
+}
+
+func (r *MemorySongRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, ok := r.songs[id]
+	if !ok || song.DeletedAt == 0 {
+		return ErrNotFound
+	}
+	song.DeletedAt = 0
+	r.songs[id] = song
+	return nil
+}
+
+func (r *MemorySongRepository) Purge(ctx context.Context, id primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	song, ok := r.songs[id]
+	if !ok || song.DeletedAt == 0 {
+		return ErrNotFound
+	}
+	delete(r.songs, id)
+	return nil
+}
+
+func matchesFilter(song *models.Song, filter SongFilter) bool {
+	return (song.DeletedAt != 0) == filter.Trashed &&
+		(filter.UploadedBy == "" || song.UploadedBy == filter.UploadedBy) &&
+		(filter.Artist == "" || song.Artist == filter.Artist) &&
+		(filter.Album == "" || song.Album == filter.Album)
+}
+
+func afterToken(song *models.Song, token *pageToken, opts ListOptions) bool {
+	c := 0
+	if opts.SortBy != SortByID {
+		c = compareValues(sortValue(song, opts.SortBy), token.Value)
+	}
+	if c == 0 {
+		c = bytes.Compare(song.ID[:], token.LastID[:])
+	}
+	if opts.Descending {
+		return c < 0
+	}
+	return c > 0
+}
+
+// compareValues orders sort values the way MongoDB does for the types songs use:
+// missing values first, then strings or dates.
+func compareValues(a, b interface{}) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	switch av := a.(type) {
+	case string:
+		if bv, ok := b.(string); ok {
+			return strings.Compare(av, bv)
+		}
+	case primitive.DateTime:
+		if bv, ok := b.(primitive.DateTime); ok {
+			switch {
+			case av < bv:
+				return -1
+			case av > bv:
+				return 1
+			}
+			return 0
+		}
+	case primitive.ObjectID:
+		if bv, ok := b.(primitive.ObjectID); ok {
+			return bytes.Compare(av[:], bv[:])
+		}
+	}
+	return 0
+}
+
+func hasTermWithPrefix(terms, prefixes []string) bool {
+	for _, term := range terms {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(term, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
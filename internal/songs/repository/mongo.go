@@ -0,0 +1,365 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+// TextWeights are the field weights of the songs text index.
+var TextWeights = map[string]float64{
+	"title":       10,
+	"artist":      5,
+	"album":       3,
+	"description": 1,
+}
+
+type MongoSongRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMongoSongRepository(db *mongo.Database) *MongoSongRepository {
+	return &MongoSongRepository{collection: db.Collection("songs")}
+}
+
+// EnsureIndexes backs every supported sort order with an index ending in _id, creates
+// the text and searchTerms indexes used by search, and backfills searchTerms on
+// documents written before it existed.
+func (r *MongoSongRepository) EnsureIndexes(ctx context.Context) error {
+	var indexes []mongo.IndexModel
+	for _, field := range []string{"uploadedBy", SortByTitle, SortByArtist, SortByUploadedAt} {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys: bson.D{{Key: field, Value: 1}, {Key: "_id", Value: 1}},
+		})
+	}
+
+	weights := bson.D{}
+	for _, field := range []string{"title", "artist", "album", "description"} {
+		weights = append(weights, bson.E{Key: field, Value: TextWeights[field]})
+	}
+	indexes = append(indexes,
+		mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "title", Value: "text"},
+				{Key: "artist", Value: "text"},
+				{Key: "album", Value: "text"},
+				{Key: "description", Value: "text"},
+			},
+			Options: options.Index().
+				SetName("song_text").
+				SetWeights(weights).
+				SetDefaultLanguage("none"),
+		},
+		mongo.IndexModel{Keys: bson.D{{Key: "searchTerms", Value: 1}}},
+		mongo.IndexModel{
+			Keys:    bson.D{{Key: "deletedAt", Value: 1}},
+			Options: options.Index().SetSparse(true),
+		},
+	)
+
+	if _, err := r.collection.Indexes().CreateMany(ctx, indexes); err != nil {
+		return err
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"searchTerms": bson.M{"$exists": false}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var song models.Song
+		if err := cursor.Decode(&song); err != nil {
+			return err
+		}
+		_, err := r.collection.UpdateOne(ctx,
+			bson.M{"_id": song.ID},
+			bson.M{"$set": bson.M{"searchTerms": SearchTerms(song.Title, song.Artist, song.Album)}},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+func (r *MongoSongRepository) Create(ctx context.Context, song *models.Song) error {
+	song.SearchTerms = SearchTerms(song.Title, song.Artist, song.Album)
+	song.Version = 1
+
+	result, err := r.collection.InsertOne(ctx, song)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+
+	if id, ok := result.InsertedID.(primitive.ObjectID); ok {
+		song.ID = id
+	}
+	return nil
+}
+
+func (r *MongoSongRepository) Get(ctx context.Context, id primitive.ObjectID) (*models.Song, error) {
+	var song models.Song
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&song)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &song, nil
+}
+
+func (r *MongoSongRepository) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	opts = opts.normalized()
+	token, err := opts.decodeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"deletedAt": bson.M{"$exists": opts.Filter.Trashed}}
+	if opts.Filter.UploadedBy != "" {
+		filter["uploadedBy"] = opts.Filter.UploadedBy
+	}
+	if opts.Filter.Artist != "" {
+		filter["artist"] = opts.Filter.Artist
+	}
+	if opts.Filter.Album != "" {
+		filter["album"] = opts.Filter.Album
+	}
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	direction := 1
+	comparison := "$gt"
+	if opts.Descending {
+		direction = -1
+		comparison = "$lt"
+	}
+
+	query := filter
+	if token != nil {
+		var after bson.M
+		switch {
+		case opts.SortBy == SortByID:
+			after = bson.M{"_id": bson.M{comparison: token.LastID}}
+		case token.Value == nil && opts.Descending:
+			// Missing values sort first, so the descending order ends with them.
+			after = bson.M{opts.SortBy: nil, "_id": bson.M{comparison: token.LastID}}
+		case token.Value == nil:
+			after = bson.M{"$or": bson.A{
+				bson.M{opts.SortBy: bson.M{"$ne": nil}},
+				bson.M{opts.SortBy: nil, "_id": bson.M{comparison: token.LastID}},
+			}}
+		default:
+			branches := bson.A{
+				bson.M{opts.SortBy: bson.M{comparison: token.Value}},
+				bson.M{opts.SortBy: token.Value, "_id": bson.M{comparison: token.LastID}},
+			}
+			if opts.Descending {
+				// $lt never matches missing values, which still follow in a descending order.
+				branches = append(branches, bson.M{opts.SortBy: nil})
+			}
+			after = bson.M{"$or": branches}
+		}
+		query = bson.M{"$and": bson.A{filter, after}}
+	}
+
+	sort := bson.D{{Key: opts.SortBy, Value: direction}}
+	if opts.SortBy != SortByID {
+		sort = append(sort, bson.E{Key: "_id", Value: direction})
+	}
+
+	cursor, err := r.collection.Find(ctx, query, options.Find().SetSort(sort).SetLimit(int64(opts.PageSize)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	songs := []*models.Song{}
+	if err := cursor.All(ctx, &songs); err != nil {
+		return nil, err
+	}
+
+	return newPage(songs, opts, total)
+}
+
+func (r *MongoSongRepository) SearchCandidates(ctx context.Context, words, prefixes []string, limit int) ([]Candidate, error) {
+	byID := map[primitive.ObjectID]*Candidate{}
+	var order []primitive.ObjectID
+
+	collect := func(cursor *mongo.Cursor) error {
+		defer cursor.Close(ctx)
+		for cursor.Next(ctx) {
+			var song models.Song
+			if err := cursor.Decode(&song); err != nil {
+				return err
+			}
+
+			candidate, ok := byID[song.ID]
+			if !ok {
+				candidate = &Candidate{Song: &song}
+				byID[song.ID] = candidate
+				order = append(order, song.ID)
+			}
+			if score, ok := cursor.Current.Lookup("score").DoubleOK(); ok {
+				candidate.TextScore = score
+			}
+		}
+		return cursor.Err()
+	}
+
+	if len(words) > 0 {
+		cursor, err := r.collection.Find(ctx,
+			bson.M{"$text": bson.M{"$search": strings.Join(words, " ")}, "deletedAt": bson.M{"$exists": false}},
+			options.Find().
+				SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+				SetSort(bson.M{"score": bson.M{"$meta": "textScore"}}).
+				SetLimit(int64(limit)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := collect(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(prefixes) > 0 {
+		var patterns bson.A
+		for _, prefix := range prefixes {
+			patterns = append(patterns, primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix)})
+		}
+		cursor, err := r.collection.Find(ctx,
+			bson.M{"searchTerms": bson.M{"$in": patterns}, "deletedAt": bson.M{"$exists": false}},
+			options.Find().SetLimit(int64(limit)),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := collect(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	candidates := make([]Candidate, 0, len(order))
+	for _, id := range order {
+		candidates = append(candidates, *byID[id])
+	}
+	return candidates, nil
+}
+
+// Update applies update to the song as read, so searchTerms always match the
+// stored fields, and writes it back only if no one else has written it since.
+func (r *MongoSongRepository) Update(ctx context.Context, id primitive.ObjectID, update SongUpdate) (*models.Song, error) {
+	song, err := r.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Version != 0 && update.Version != song.Version {
+		return nil, ErrVersionConflict
+	}
+
+	// Songs written before versioning have no version field.
+	current := bson.M{"_id": id, "version": song.Version}
+	if song.Version == 0 {
+		current["version"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	update.apply(song)
+	song.Version++
+	result, err := r.collection.UpdateOne(ctx, current, bson.M{
+		"$set": bson.M{
+			"title":       song.Title,
+			"artist":      song.Artist,
+			"album":       song.Album,
+			"description": song.Description,
+			"searchTerms": song.SearchTerms,
+			"version":     song.Version,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, ErrVersionConflict
+	}
+	return song, nil
+}
+
+func (r *MongoSongRepository) Trash(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deletedAt": primitive.NewDateTimeFromTime(at)}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoSongRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "deletedAt": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"deletedAt": ""}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *MongoSongRepository) Purge(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "deletedAt": bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// newPage trims the extra song fetched to detect a following page and builds its token.
+func newPage(songs []*models.Song, opts ListOptions, total int64) (*Page, error) {
+	page := &Page{Songs: songs, TotalCount: total}
+	if len(songs) <= opts.PageSize {
+		return page, nil
+	}
+
+	page.Songs = songs[:opts.PageSize]
+	last := page.Songs[len(page.Songs)-1]
+
+	token, err := encodeToken(pageToken{
+		SortField:  opts.SortBy,
+		Descending: opts.Descending,
+		Value:      sortValue(last, opts.SortBy),
+		LastID:     last.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	page.NextPageToken = token
+	return page, nil
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+const (
+	SortByID         = "_id"
+	SortByTitle      = "title"
+	SortByArtist     = "artist"
+	SortByUploadedAt = "uploadedAt"
+)
+
+var (
+	ErrNotFound         = errors.New("song not found")
+	ErrAlreadyExists    = errors.New("song already exists")
+	ErrVersionConflict  = errors.New("song was modified concurrently")
+	ErrInvalidPageToken = errors.New("invalid page token")
+)
+
+// SongRepository is the storage behind the songs service.
+type SongRepository interface {
+	// Create stores song and sets its ID. If song.ID is already set and taken,
+	// it returns ErrAlreadyExists.
+	Create(ctx context.Context, song *models.Song) error
+	Get(ctx context.Context, id primitive.ObjectID) (*models.Song, error)
+	List(ctx context.Context, opts ListOptions) (*Page, error)
+	// SearchCandidates returns songs that may match the normalized query words,
+	// either through the text index or through a stored term starting with one of prefixes.
+	SearchCandidates(ctx context.Context, words, prefixes []string, limit int) ([]Candidate, error)
+	// Update changes the fields named by update and returns the song as
+	// updated. It returns ErrVersionConflict if the song is not at
+	// update.Version or changes while being updated.
+	Update(ctx context.Context, id primitive.ObjectID, update SongUpdate) (*models.Song, error)
+	// Trash moves a song to the trash at the given time. Trashed songs are
+	// left out of List, unless SongFilter.Trashed asks for them, and of
+	// SearchCandidates. It returns ErrNotFound if the song is already trashed.
+	Trash(ctx context.Context, id primitive.ObjectID, at time.Time) error
+	// Restore takes a song out of the trash, or returns ErrNotFound if it is
+	// not trashed.
+	Restore(ctx context.Context, id primitive.ObjectID) error
+	// Purge permanently deletes a trashed song, or returns ErrNotFound if it
+	// is not trashed.
+	Purge(ctx context.Context, id primitive.ObjectID) error
+}
+
+type SongFilter struct {
+	UploadedBy string
+	Artist     string
+	Album      string
+	// Trashed lists trashed songs instead of live ones.
+	Trashed bool
+}
+
+type ListOptions struct {
+	Filter     SongFilter
+	SortBy     string
+	Descending bool
+	PageSize   int
+	PageToken  string
+}
+
+type Page struct {
+	Songs         []*models.Song
+	NextPageToken string
+	TotalCount    int64
+}
+
+// Song fields that can be updated, named as in a SongUpdate field mask.
+const (
+	FieldTitle       = "title"
+	FieldArtist      = "artist"
+	FieldAlbum       = "album"
+	FieldDescription = "description"
+)
+
+var UpdatableFields = []string{FieldTitle, FieldArtist, FieldAlbum, FieldDescription}
+
+type SongUpdate struct {
+	Title       string
+	Artist      string
+	Album       string
+	Description string
+	// Fields lists the fields to change; the others are left alone.
+	Fields []string
+	// Version is the version the caller expects the song to be at; 0 skips
+	// the check.
+	Version int64
+}
+
+// apply copies the fields named by u onto song.
+func (u SongUpdate) apply(song *models.Song) {
+	for _, field := range u.Fields {
+		switch field {
+		case FieldTitle:
+			song.Title = u.Title
+		case FieldArtist:
+			song.Artist = u.Artist
+		case FieldAlbum:
+			song.Album = u.Album
+		case FieldDescription:
+			song.Description = u.Description
+		}
+	}
+	song.SearchTerms = SearchTerms(song.Title, song.Artist, song.Album)
+}
+
+type Candidate struct {
+	Song      *models.Song
+	TextScore float64
+}
+
+// pageToken is the keyset position after the last song of a page. It is
+// BSON-encoded so the sort value keeps its type (string or date).
+type pageToken struct {
+	SortField  string             `bson:"s"`
+	Descending bool               `bson:"d"`
+	Value      interface{}        `bson:"v"`
+	LastID     primitive.ObjectID `bson:"id"`
+}
+
+func (o ListOptions) normalized() ListOptions {
+	if o.PageSize <= 0 {
+		o.PageSize = DefaultPageSize
+	}
+	if o.PageSize > MaxPageSize {
+		o.PageSize = MaxPageSize
+	}
+	if o.SortBy == "" {
+		o.SortBy = SortByID
+	}
+	return o
+}
+
+func (o ListOptions) decodeToken() (*pageToken, error) {
+	if o.PageToken == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(o.PageToken)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+
+	var token pageToken
+	if err := bson.Unmarshal(data, &token); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if token.SortField != o.SortBy || token.Descending != o.Descending {
+		return nil, ErrInvalidPageToken
+	}
+	return &token, nil
+}
+
+func encodeToken(token pageToken) (string, error) {
+	data, err := bson.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func sortValue(song *models.Song, field string) interface{} {
+	switch field {
+	case SortByTitle:
+		return song.Title
+	case SortByArtist:
+		return song.Artist
+	case SortByUploadedAt:
+		if song.UploadedAt == 0 {
+			return nil
+		}
+		return song.UploadedAt
+	default:
+		return song.ID
+	}
+}
+
+// SearchTerms is the normalized, de-duplicated word list stored on every song.
+func SearchTerms(fields ...string) []string {
+	seen := map[string]bool{}
+	terms := []string{}
+	for _, field := range fields {
+		for _, word := range Tokenize(field) {
+			if !seen[word] {
+				seen[word] = true
+				terms = append(terms, word)
+			}
+		}
+	}
+	return terms
+}
+
+// Tokenize lowercases, strips diacritics and splits on anything that is not a letter or digit.
+func Tokenize(s string) []string {
+	folded, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), s)
+	if err != nil {
+		folded = s
+	}
+
+	return strings.FieldsFunc(strings.ToLower(folded), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
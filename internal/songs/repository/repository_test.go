@@ -0,0 +1,446 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+// mongoTestURIEnv points the tests at a MongoDB server; the Mongo
+// implementation is skipped without it.
+const mongoTestURIEnv = "MONGO_TEST_URI"
+
+// newMongoTestRepository returns a repository on a throwaway database, or nil
+// if no server is configured.
+func newMongoTestRepository(t *testing.T) *MongoSongRepository {
+	t.Helper()
+
+	uri := os.Getenv(mongoTestURIEnv)
+	if uri == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	if err != nil {
+		t.Fatalf("failed to connect to MongoDB: %v", err)
+	}
+	db := client.Database("songs_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+
+	repo := NewMongoSongRepository(db)
+	if err := repo.EnsureIndexes(ctx); err != nil {
+		t.Fatalf("failed to create indexes: %v", err)
+	}
+	return repo
+}
+
+// forEachRepository runs test against every implementation, each filled with
+// its own copy of songs.
+func forEachRepository(t *testing.T, songs []models.Song, test func(t *testing.T, repo SongRepository)) {
+	repos := map[string]func(t *testing.T) SongRepository{
+		"memory": func(t *testing.T) SongRepository { return NewMemorySongRepository() },
+		"mongo": func(t *testing.T) SongRepository {
+			repo := newMongoTestRepository(t)
+			if repo == nil {
+				t.Skipf("%s is not set", mongoTestURIEnv)
+			}
+			return repo
+		},
+	}
+
+	for name, newRepo := range repos {
+		t.Run(name, func(t *testing.T) {
+			repo := newRepo(t)
+			for _, song := range songs {
+				song := song
+				if err := repo.Create(context.Background(), &song); err != nil {
+					t.Fatalf("Create(%q) failed: %v", song.Title, err)
+				}
+			}
+			test(t, repo)
+		})
+	}
+}
+
+// listAll pages through List and returns the IDs in the order they came.
+func listAll(t *testing.T, repo SongRepository, opts ListOptions) []primitive.ObjectID {
+	t.Helper()
+
+	var ids []primitive.ObjectID
+	for pages := 0; ; pages++ {
+		if pages > 100 {
+			t.Fatalf("List did not finish paging")
+		}
+		page, err := repo.List(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("List failed: %v", err)
+		}
+		for _, song := range page.Songs {
+			ids = append(ids, song.ID)
+		}
+		if page.NextPageToken == "" {
+			return ids
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+func at(minutes int) primitive.DateTime {
+	return primitive.NewDateTimeFromTime(time.Date(2024, 1, 1, 0, minutes, 0, 0, time.UTC))
+}
+
+// paritySongs share sort values and leave some upload times out, so the
+// keyset has to break ties on _id and page past missing values.
+func paritySongs() []models.Song {
+	return []models.Song{
+		{ID: primitive.NewObjectID(), Title: "b", Artist: "x", UploadedAt: at(1)},
+		{ID: primitive.NewObjectID(), Title: "a", Artist: "x"},
+		{ID: primitive.NewObjectID(), Title: "b", Artist: "y", UploadedAt: at(1)},
+		{ID: primitive.NewObjectID(), Title: "c", Artist: "x"},
+		{ID: primitive.NewObjectID(), Title: "a", Artist: "z", UploadedAt: at(2)},
+		{ID: primitive.NewObjectID(), Title: "d", Artist: "y", UploadedAt: at(3)},
+		{ID: primitive.NewObjectID(), Title: "c", Artist: "z"},
+	}
+}
+
+func TestListPagingParity(t *testing.T) {
+	songs := paritySongs()
+
+	cases := []struct {
+		sortBy     string
+		descending bool
+	}{
+		{SortByID, false},
+		{SortByID, true},
+		{SortByTitle, false},
+		{SortByTitle, true},
+		{SortByArtist, false},
+		{SortByArtist, true},
+		{SortByUploadedAt, false},
+		{SortByUploadedAt, true},
+	}
+
+	results := map[string]map[string][]primitive.ObjectID{}
+	forEachRepository(t, songs, func(t *testing.T, repo SongRepository) {
+		byCase := map[string][]primitive.ObjectID{}
+		for _, c := range cases {
+			name := c.sortBy
+			if c.descending {
+				name += " desc"
+			}
+			// One page in one go gives the order the paged run must follow.
+			want := listAll(t, repo, ListOptions{SortBy: c.sortBy, Descending: c.descending, PageSize: len(songs)})
+			got := listAll(t, repo, ListOptions{SortBy: c.sortBy, Descending: c.descending, PageSize: 2})
+			if len(want) != len(songs) {
+				t.Errorf("%s: single page returned %d songs, want %d", name, len(want), len(songs))
+			}
+			if !equalIDs(got, want) {
+				t.Errorf("%s: paged order %v, want %v", name, got, want)
+			}
+			byCase[name] = got
+		}
+		results[t.Name()] = byCase
+	})
+
+	memory, mongo := results[t.Name()+"/memory"], results[t.Name()+"/mongo"]
+	if memory == nil || mongo == nil {
+		return
+	}
+	for name, want := range memory {
+		if got := mongo[name]; !equalIDs(got, want) {
+			t.Errorf("%s: mongo order %v, memory order %v", name, got, want)
+		}
+	}
+}
+
+func equalIDs(a, b []primitive.ObjectID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func listSongs() []models.Song {
+	return []models.Song{
+		{Title: "Blue", Artist: "Ann", Album: "One", UploadedBy: "u1", UploadedAt: at(3)},
+		{Title: "Amber", Artist: "Bob", Album: "Two", UploadedBy: "u2", UploadedAt: at(1)},
+		{Title: "Dusk", Artist: "Ann", Album: "Two", UploadedBy: "u1", UploadedAt: at(4)},
+		{Title: "Coral", Artist: "Cid", Album: "One", UploadedBy: "u2", UploadedAt: at(2)},
+		{Title: "Ember", Artist: "Ann", Album: "One", UploadedBy: "u2", UploadedAt: at(5)},
+	}
+}
+
+func TestList(t *testing.T) {
+	cases := []struct {
+		name      string
+		opts      ListOptions
+		want      []string
+		wantPages int
+	}{
+		{
+			name:      "title ascending",
+			opts:      ListOptions{SortBy: SortByTitle, PageSize: 2},
+			want:      []string{"Amber", "Blue", "Coral", "Dusk", "Ember"},
+			wantPages: 3,
+		},
+		{
+			name:      "title descending",
+			opts:      ListOptions{SortBy: SortByTitle, Descending: true, PageSize: 2},
+			want:      []string{"Ember", "Dusk", "Coral", "Blue", "Amber"},
+			wantPages: 3,
+		},
+		{
+			name:      "upload time descending",
+			opts:      ListOptions{SortBy: SortByUploadedAt, Descending: true, PageSize: 3},
+			want:      []string{"Ember", "Dusk", "Blue", "Coral", "Amber"},
+			wantPages: 2,
+		},
+		{
+			name:      "artist then id",
+			opts:      ListOptions{SortBy: SortByArtist, PageSize: 1},
+			want:      []string{"Blue", "Dusk", "Ember", "Amber", "Coral"},
+			wantPages: 5,
+		},
+		{
+			name:      "filter by uploader",
+			opts:      ListOptions{Filter: SongFilter{UploadedBy: "u1"}, SortBy: SortByTitle, PageSize: 1},
+			want:      []string{"Blue", "Dusk"},
+			wantPages: 2,
+		},
+		{
+			name:      "filter by artist and album",
+			opts:      ListOptions{Filter: SongFilter{Artist: "Ann", Album: "One"}, SortBy: SortByTitle},
+			want:      []string{"Blue", "Ember"},
+			wantPages: 1,
+		},
+		{
+			name:      "no match",
+			opts:      ListOptions{Filter: SongFilter{Artist: "Nobody"}},
+			want:      nil,
+			wantPages: 1,
+		},
+	}
+
+	forEachRepository(t, listSongs(), func(t *testing.T, repo SongRepository) {
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				opts := c.opts
+				var got []string
+				pages := 0
+				for {
+					page, err := repo.List(context.Background(), opts)
+					if err != nil {
+						t.Fatalf("List failed: %v", err)
+					}
+					pages++
+					if page.TotalCount != int64(len(c.want)) {
+						t.Errorf("TotalCount = %d, want %d", page.TotalCount, len(c.want))
+					}
+					for _, song := range page.Songs {
+						got = append(got, song.Title)
+					}
+					if page.NextPageToken == "" || pages > len(c.want) {
+						break
+					}
+					opts.PageToken = page.NextPageToken
+				}
+
+				if !equalStrings(got, c.want) {
+					t.Errorf("titles = %v, want %v", got, c.want)
+				}
+				if pages != c.wantPages {
+					t.Errorf("pages = %d, want %d", pages, c.wantPages)
+				}
+			})
+		}
+
+		t.Run("token from another sort", func(t *testing.T) {
+			page, err := repo.List(context.Background(), ListOptions{SortBy: SortByTitle, PageSize: 1})
+			if err != nil {
+				t.Fatalf("List failed: %v", err)
+			}
+			_, err = repo.List(context.Background(), ListOptions{SortBy: SortByArtist, PageSize: 1, PageToken: page.NextPageToken})
+			if err != ErrInvalidPageToken {
+				t.Errorf("List error = %v, want %v", err, ErrInvalidPageToken)
+			}
+		})
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []struct {
+		name    string
+		update  SongUpdate
+		want    models.Song
+		wantErr error
+	}{
+		{
+			name:   "current version",
+			update: SongUpdate{Title: "New", Fields: []string{FieldTitle}, Version: 1},
+			want:   models.Song{Title: "New", Artist: "Ann", Album: "One", Version: 2},
+		},
+		{
+			name:   "unchecked version",
+			update: SongUpdate{Artist: "Bob", Album: "Two", Fields: []string{FieldArtist, FieldAlbum}},
+			want:   models.Song{Title: "Old", Artist: "Bob", Album: "Two", Version: 2},
+		},
+		{
+			name:   "field cleared",
+			update: SongUpdate{Fields: []string{FieldAlbum}, Version: 1},
+			want:   models.Song{Title: "Old", Artist: "Ann", Version: 2},
+		},
+		{
+			name:   "no fields",
+			update: SongUpdate{Title: "New", Artist: "Bob", Version: 1},
+			want:   models.Song{Title: "Old", Artist: "Ann", Album: "One", Version: 2},
+		},
+		{
+			name:    "stale version",
+			update:  SongUpdate{Title: "New", Fields: []string{FieldTitle}, Version: 2},
+			want:    models.Song{Title: "Old", Artist: "Ann", Album: "One", Version: 1},
+			wantErr: ErrVersionConflict,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			song := models.Song{ID: primitive.NewObjectID(), Title: "Old", Artist: "Ann", Album: "One"}
+			forEachRepository(t, []models.Song{song}, func(t *testing.T, repo SongRepository) {
+				updated, err := repo.Update(context.Background(), song.ID, c.update)
+				if err != c.wantErr {
+					t.Fatalf("Update error = %v, want %v", err, c.wantErr)
+				}
+				if err == nil && updated.Version != c.want.Version {
+					t.Errorf("returned version = %d, want %d", updated.Version, c.want.Version)
+				}
+
+				stored, err := repo.Get(context.Background(), song.ID)
+				if err != nil {
+					t.Fatalf("Get failed: %v", err)
+				}
+				if stored.Title != c.want.Title || stored.Artist != c.want.Artist || stored.Album != c.want.Album || stored.Version != c.want.Version {
+					t.Errorf("stored = %q/%q/%q v%d, want %q/%q/%q v%d",
+						stored.Title, stored.Artist, stored.Album, stored.Version,
+						c.want.Title, c.want.Artist, c.want.Album, c.want.Version)
+				}
+			})
+		})
+	}
+
+	t.Run("missing song", func(t *testing.T) {
+		forEachRepository(t, nil, func(t *testing.T, repo SongRepository) {
+			_, err := repo.Update(context.Background(), primitive.NewObjectID(), SongUpdate{Fields: []string{FieldTitle}})
+			if err != ErrNotFound {
+				t.Errorf("Update error = %v, want %v", err, ErrNotFound)
+			}
+		})
+	})
+}
+
+func TestTrash(t *testing.T) {
+	type step struct {
+		op      string
+		wantErr error
+	}
+	cases := []struct {
+		name        string
+		steps       []step
+		wantLive    bool
+		wantTrashed bool
+	}{
+		{
+			name:        "trash",
+			steps:       []step{{"trash", nil}},
+			wantTrashed: true,
+		},
+		{
+			name:        "trash twice",
+			steps:       []step{{"trash", nil}, {"trash", ErrNotFound}},
+			wantTrashed: true,
+		},
+		{
+			name:     "restore",
+			steps:    []step{{"trash", nil}, {"restore", nil}},
+			wantLive: true,
+		},
+		{
+			name:     "restore live song",
+			steps:    []step{{"restore", ErrNotFound}},
+			wantLive: true,
+		},
+		{
+			name:     "purge live song",
+			steps:    []step{{"purge", ErrNotFound}},
+			wantLive: true,
+		},
+		{
+			name:  "purge",
+			steps: []step{{"trash", nil}, {"purge", nil}, {"purge", ErrNotFound}, {"restore", ErrNotFound}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			song := models.Song{ID: primitive.NewObjectID(), Title: "Song"}
+			forEachRepository(t, []models.Song{song}, func(t *testing.T, repo SongRepository) {
+				ctx := context.Background()
+				for _, s := range c.steps {
+					var err error
+					switch s.op {
+					case "trash":
+						err = repo.Trash(ctx, song.ID, time.Now())
+					case "restore":
+						err = repo.Restore(ctx, song.ID)
+					case "purge":
+						err = repo.Purge(ctx, song.ID)
+					}
+					if err != s.wantErr {
+						t.Fatalf("%s error = %v, want %v", s.op, err, s.wantErr)
+					}
+				}
+
+				if live := listAll(t, repo, ListOptions{}); (len(live) == 1) != c.wantLive {
+					t.Errorf("listed as live = %v, want %v", len(live) == 1, c.wantLive)
+				}
+				if trashed := listAll(t, repo, ListOptions{Filter: SongFilter{Trashed: true}}); (len(trashed) == 1) != c.wantTrashed {
+					t.Errorf("listed as trashed = %v, want %v", len(trashed) == 1, c.wantTrashed)
+				}
+				if _, err := repo.Get(ctx, song.ID); (err == nil) != (c.wantLive || c.wantTrashed) {
+					t.Errorf("Get error = %v after %v", err, c.steps)
+				}
+			})
+		})
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
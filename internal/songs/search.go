@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/maksymshtarkberg/music-player-go/internal/grpc-server/proto"
+	"github.com/maksymshtarkberg/music-player-go/internal/natsrpc"
+	"github.com/maksymshtarkberg/music-player-go/internal/songs/repository"
+	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+)
+
+const (
+	defaultSearchPageSize = 20
+	maxSearchPageSize     = 100
+	// Upper bound on documents ranked per query; results past it are not
+	// reachable and the response is marked truncated.
+	maxSearchCandidates = 1000
+	minFuzzyPrefixLen   = 2
+)
+
+type rankedSong struct {
+	song  *models.Song
+	score float64
+}
+
+func HandleSearchSongs(nc *nats.Conn, songs repository.SongRepository) func(m *nats.Msg) {
+	return func(m *nats.Msg) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		var req pb.SearchSongsRequest
+		if err := proto.Unmarshal(m.Data, &req); err != nil {
+			natsrpc.RespondError(m, codes.InvalidArgument, "Failed to unmarshal request")
+			return
+		}
+
+		response, err := searchSongs(ctx, songs, &req)
+		if err != nil {
+			log.Printf("Failed to search songs for %q: %v", req.GetQuery(), err)
+			respondStatus(m, err)
+			return
+		}
+
+		responseData, err := proto.Marshal(response)
+		if err != nil {
+			log.Printf("Failed to marshal response: %v", err)
+			natsrpc.RespondError(m, codes.Internal, err.Error())
+			return
+		}
+
+		m.Respond(responseData)
+	}
+}
+
+// searchSongs gathers candidates from the text index and from searchTerms prefixes,
+// ranks them in process so prefix and near-miss spellings still score, and pages
+// through the ranked list by offset. The page token carries a hash of the
+// normalized query so it cannot be replayed against another one.
+func searchSongs(ctx context.Context, songs repository.SongRepository, req *pb.SearchSongsRequest) (*pb.SearchSongsResponse, error) {
+	words := repository.Tokenize(req.GetQuery())
+	if len(words) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "query must contain at least one letter or digit")
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultSearchPageSize
+	}
+	if pageSize > maxSearchPageSize {
+		pageSize = maxSearchPageSize
+	}
+
+	queryHash := searchQueryHash(words)
+	offset := 0
+	if req.GetPageToken() != "" {
+		var err error
+		offset, err = decodeSearchToken(req.GetPageToken(), queryHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Near-miss and partial words share a short prefix with the stored term.
+	prefixes := make([]string, 0, len(words))
+	for _, word := range words {
+		prefix := []rune(word)
+		if len(prefix) > minFuzzyPrefixLen {
+			prefix = prefix[:minFuzzyPrefixLen]
+		}
+		prefixes = append(prefixes, string(prefix))
+	}
+
+	// One extra candidate tells whether the limit cut the results short.
+	candidates, err := songs.SearchCandidates(ctx, words, prefixes, maxSearchCandidates+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "search failed: %v", err)
+	}
+	truncated := len(candidates) > maxSearchCandidates
+	if truncated {
+		candidates = candidates[:maxSearchCandidates]
+	}
+
+	ranked := make([]rankedSong, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score := rankCandidate(candidate, words); score > 0 {
+			ranked = append(ranked, rankedSong{song: candidate.Song, score: score})
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].song.ID.Hex() > ranked[j].song.ID.Hex()
+	})
+
+	response := &pb.SearchSongsResponse{
+		Songs:      []*pb.SongMetadata{},
+		TotalCount: int64(len(ranked)),
+		Truncated:  truncated,
+	}
+	if offset >= len(ranked) {
+		return response, nil
+	}
+
+	end := offset + pageSize
+	if end > len(ranked) {
+		end = len(ranked)
+	}
+	for _, result := range ranked[offset:end] {
+		response.Songs = append(response.Songs, songToProto(result.song))
+	}
+	if end < len(ranked) {
+		response.NextPageToken = encodeSearchToken(end, queryHash)
+	}
+
+	return response, nil
+}
+
+// searchQueryHash identifies the normalized query a page token was issued for.
+func searchQueryHash(words []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:8])
+}
+
+func encodeSearchToken(offset int, queryHash string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + "." + queryHash))
+}
+
+func decodeSearchToken(token, queryHash string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	offsetStr, hash, ok := strings.Cut(string(decoded), ".")
+	offset, err := strconv.Atoi(offsetStr)
+	if !ok || err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "malformed page token")
+	}
+	if hash != queryHash {
+		return 0, status.Error(codes.InvalidArgument, "page token belongs to a different query")
+	}
+	return offset, nil
+}
+
+// rankCandidate scores every query word against the best matching word of each
+// field: exact matches count fully, prefixes (autocomplete) and words within a small
+// edit distance (typos) count partially. The text index score breaks ties.
+func rankCandidate(candidate repository.Candidate, words []string) float64 {
+	fields := map[string][]string{
+		"title":       repository.Tokenize(candidate.Song.Title),
+		"artist":      repository.Tokenize(candidate.Song.Artist),
+		"album":       repository.Tokenize(candidate.Song.Album),
+		"description": repository.Tokenize(candidate.Song.Description),
+	}
+
+	score := 0.0
+	for i, word := range words {
+		isLast := i == len(words)-1
+		best := 0.0
+		for field, terms := range fields {
+			for _, term := range terms {
+				match := matchQuality(word, term, isLast) * repository.TextWeights[field]
+				if match > best {
+					best = match
+				}
+			}
+		}
+		score += best
+	}
+
+	if score == 0 {
+		return 0
+	}
+	return score + candidate.TextScore
+}
+
+func matchQuality(word, term string, allowPrefix bool) float64 {
+	switch {
+	case word == term:
+		return 1
+	case allowPrefix && len(term) > len(word) && term[:len(word)] == word:
+		return 0.75
+	}
+
+	allowed := 1
+	if len([]rune(word)) >= 6 {
+		allowed = 2
+	}
+	if len([]rune(word)) >= 4 && levenshtein(word, term, allowed) <= allowed {
+		return 0.5
+	}
+	return 0
+}
+
+// levenshtein returns the edit distance between a and b, stopping early once it is
+// certain to exceed limit.
+func levenshtein(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if abs(len(ra)-len(rb)) > limit {
+		return limit + 1
+	}
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if curr[j] < rowMin {
+				rowMin = curr[j]
+			}
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,11 @@
+package storage
+
+// Collections shared by the services that store songs and the jobs that
+// reconcile them with GridFS.
+const (
+	SongsCollection          = "songs"
+	BlobsCollection          = "blobs"
+	WaveformsCollection      = "song_waveforms"
+	UploadSessionsCollection = "upload_sessions"
+	FilesCollection          = "fs.files"
+)
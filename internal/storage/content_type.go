@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// DetectContentType prefers a content type recorded in the GridFS file metadata and
+// otherwise sniffs the first bytes of the file.
+func DetectContentType(bucket *gridfs.Bucket, file *gridfs.File) (string, error) {
+	if file.Metadata != nil {
+		if contentType, ok := file.Metadata.Lookup("contentType").StringValueOK(); ok && contentType != "" {
+			return contentType, nil
+		}
+	}
+
+	downloadStream, err := bucket.OpenDownloadStream(file.ID)
+	if err != nil {
+		return "", err
+	}
+	defer downloadStream.Close()
+
+	head := make([]byte, SniffLen)
+	n, err := io.ReadFull(downloadStream, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	return SniffContentType(head[:n]), nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"errors"
+	"io"
+
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+)
+
+// ReadSeeker adapts a GridFS file to io.ReadSeeker so it can be served with
+// http.ServeContent. GridFS download streams only move forward, so Seek only
+// records the position and the next Read reopens or skips the stream as needed.
+type ReadSeeker struct {
+	bucket    *gridfs.Bucket
+	file      *gridfs.File
+	stream    *gridfs.DownloadStream
+	streamPos int64
+	pos       int64
+}
+
+func NewReadSeeker(bucket *gridfs.Bucket, fileID interface{}) (*ReadSeeker, error) {
+	stream, err := bucket.OpenDownloadStream(fileID)
+	if err != nil {
+		return nil, err
+	}
+	return &ReadSeeker{bucket: bucket, file: stream.GetFile(), stream: stream}, nil
+}
+
+func (r *ReadSeeker) File() *gridfs.File {
+	return r.file
+}
+
+func (r *ReadSeeker) Read(p []byte) (int, error) {
+	if r.pos >= r.file.Length {
+		return 0, io.EOF
+	}
+
+	if r.streamPos > r.pos {
+		if err := r.reopen(); err != nil {
+			return 0, err
+		}
+	}
+	if r.streamPos < r.pos {
+		skipped, err := r.stream.Skip(r.pos - r.streamPos)
+		r.streamPos += skipped
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.stream.Read(p)
+	r.streamPos += int64(n)
+	r.pos += int64(n)
+	return n, err
+}
+
+func (r *ReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	var target int64
+	switch whence {
+	case io.SeekStart:
+		target = offset
+	case io.SeekCurrent:
+		target = r.pos + offset
+	case io.SeekEnd:
+		target = r.file.Length + offset
+	default:
+		return 0, errors.New("storage: invalid whence")
+	}
+	if target < 0 {
+		return 0, errors.New("storage: negative position")
+	}
+
+	r.pos = target
+	return target, nil
+}
+
+func (r *ReadSeeker) Close() error {
+	return r.stream.Close()
+}
+
+func (r *ReadSeeker) reopen() error {
+	r.stream.Close()
+
+	stream, err := r.bucket.OpenDownloadStream(r.file.ID)
+	if err != nil {
+		return err
+	}
+
+	r.stream = stream
+	r.streamPos = 0
+	return nil
+}
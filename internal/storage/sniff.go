@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// SniffLen is the number of leading bytes SniffContentType looks at.
+const SniffLen = 512
+
+// SniffContentType extends http.DetectContentType with the audio containers it
+// does not recognise (FLAC, bare MP3 frames, MP4/M4A) and WebP images.
+func SniffContentType(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return "audio/flac"
+	case bytes.HasPrefix(head, []byte("ID3")):
+		return "audio/mpeg"
+	case len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0 && head[1]&0x06 != 0:
+		// MPEG audio frame sync with a valid layer.
+		return "audio/mpeg"
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return "audio/ogg"
+	case len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")):
+		switch string(head[8:12]) {
+		case "M4A ", "M4B ", "M4P ":
+			return "audio/mp4"
+		}
+		return "video/mp4"
+	case len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WEBP")):
+		return "image/webp"
+	case len(head) >= 12 && bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return "audio/wav"
+	}
+
+	return http.DetectContentType(head)
+}
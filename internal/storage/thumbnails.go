@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/maksymshtarkberg/music-player-go/internal/media"
+)
+
+// ThumbnailSizes are the square edge lengths, in pixels, generated for every
+// album cover.
+var ThumbnailSizes = []int{64, 256, 640}
+
+// Thumbnails are regular GridFS files pointing back at their cover through
+// these metadata fields.
+const (
+	thumbnailOfField   = "metadata.thumbnailOf"
+	thumbnailSizeField = "metadata.thumbnailSize"
+)
+
+// EnsureThumbnailIndex indexes thumbnail lookups on the GridFS files collection.
+func EnsureThumbnailIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection(FilesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: thumbnailOfField, Value: 1}, {Key: thumbnailSizeField, Value: 1}},
+	})
+	return err
+}
+
+// StoreThumbnails decodes a cover and stores one JPEG thumbnail per entry in
+// ThumbnailSizes. It returns media.ErrInvalidImage when data is not an image.
+func StoreThumbnails(bucket *gridfs.Bucket, coverID primitive.ObjectID, data []byte) error {
+	img, _, err := media.DecodeImage(data)
+	if err != nil {
+		return err
+	}
+
+	for _, size := range ThumbnailSizes {
+		thumbnail, err := media.Thumbnail(img, size)
+		if err != nil {
+			return err
+		}
+
+		metadata := bson.M{
+			"contentType":   "image/jpeg",
+			"thumbnailOf":   coverID,
+			"thumbnailSize": size,
+		}
+		filename := fmt.Sprintf("%s-%d.jpg", coverID.Hex(), size)
+		_, err = bucket.UploadFromStream(filename, bytes.NewReader(thumbnail), options.GridFSUpload().SetMetadata(metadata))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FindThumbnail returns the ID of the thumbnail of coverID with the given
+// size, or gridfs.ErrFileNotFound.
+func FindThumbnail(ctx context.Context, bucket *gridfs.Bucket, coverID primitive.ObjectID, size int) (primitive.ObjectID, error) {
+	cursor, err := bucket.FindContext(ctx, bson.M{thumbnailOfField: coverID, thumbnailSizeField: size}, options.GridFSFind().SetLimit(1))
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	defer cursor.Close(ctx)
+
+	var file gridfs.File
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return primitive.NilObjectID, err
+		}
+		return primitive.NilObjectID, gridfs.ErrFileNotFound
+	}
+	if err := cursor.Decode(&file); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	id, ok := file.ID.(primitive.ObjectID)
+	if !ok {
+		return primitive.NilObjectID, gridfs.ErrFileNotFound
+	}
+	return id, nil
+}
+
+// DeleteThumbnails removes every thumbnail generated for coverID.
+func DeleteThumbnails(ctx context.Context, bucket *gridfs.Bucket, coverID primitive.ObjectID) error {
+	cursor, err := bucket.FindContext(ctx, bson.M{thumbnailOfField: coverID})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var file gridfs.File
+		if err := cursor.Decode(&file); err != nil {
+			return err
+		}
+		if err := bucket.DeleteContext(ctx, file.ID); err != nil && err != gridfs.ErrFileNotFound {
+			return err
+		}
+	}
+	return cursor.Err()
+}
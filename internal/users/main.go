@@ -2,8 +2,6 @@ package main
 
 import (
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -11,7 +9,10 @@ import (
 
 	"github.com/maksymshtarkberg/music-player-go/internal/database"
 	"github.com/maksymshtarkberg/music-player-go/pkg/models"
+	"github.com/maksymshtarkberg/music-player-go/pkg/password"
 	"github.com/nats-io/nats.go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -32,18 +33,13 @@ func main() {
 
 	nc.Subscribe("users.register", handleRegister)
 	nc.Subscribe("users.get", handleGetUser)
+	nc.Subscribe("users.rehash", handleRehashPassword)
 
 	log.Println("Server users is running...")
 
 	select {}
 }
 
-func HashPassword(password string) string {
-	hash := sha256.New()
-	hash.Write([]byte(password))
-	return hex.EncodeToString(hash.Sum(nil))
-}
-
 func handleRegister(m *nats.Msg) {
 
 	var user models.User
@@ -58,9 +54,14 @@ func handleRegister(m *nats.Msg) {
 
 	collection := database.GetCollection("users")
 
-	user.Password = HashPassword(user.Password)
+	user.Password, err = password.Hash(user.Password)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to hash password: %v"}`, err)))
+		return
+	}
+	user.Roles = []string{models.RoleUser}
 
-	_, err = collection.InsertOne(ctx, user)
+	result, err := collection.InsertOne(ctx, user)
 	if err != nil {
 		if mongo.IsDuplicateKeyError(err) {
 			nc.Publish(m.Reply, []byte(`{"error": "User already exists"}`))
@@ -70,10 +71,15 @@ func handleRegister(m *nats.Msg) {
 		return
 	}
 
-	responseData, err := json.Marshal(map[string]string{
+	if insertedID, ok := result.InsertedID.(primitive.ObjectID); ok {
+		user.ID = insertedID
+	}
+
+	responseData, err := json.Marshal(map[string]interface{}{
 		"status":   "User registered successfully",
 		"userID":   user.ID.Hex(),
 		"userName": user.Username,
+		"roles":    user.Roles,
 	})
 	if err != nil {
 		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to serialize response: %v"}`, err)))
@@ -105,3 +111,44 @@ func handleGetUser(m *nats.Msg) {
 	responseData, _ := json.Marshal(user)
 	nc.Publish(m.Reply, responseData)
 }
+
+// handleRehashPassword replaces a user's stored hash, but only if it still matches the
+// hash the caller verified against, so a concurrent password change is never overwritten.
+func handleRehashPassword(m *nats.Msg) {
+	var request struct {
+		Username     string `json:"username"`
+		PreviousHash string `json:"previousHash"`
+		PasswordHash string `json:"passwordHash"`
+	}
+	err := json.Unmarshal(m.Data, &request)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Invalid data: %v"}`, err)))
+		return
+	}
+
+	if request.Username == "" || request.PreviousHash == "" || request.PasswordHash == "" {
+		nc.Publish(m.Reply, []byte(`{"error": "Invalid data: username, previousHash and passwordHash are required"}`))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	collection := database.GetCollection("users")
+
+	filter := bson.M{"username": request.Username, "password": request.PreviousHash}
+	update := bson.M{"$set": bson.M{"password": request.PasswordHash}}
+
+	result, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		nc.Publish(m.Reply, []byte(fmt.Sprintf(`{"error": "Failed to update password: %v"}`, err)))
+		return
+	}
+
+	if result.ModifiedCount == 0 {
+		nc.Publish(m.Reply, []byte(`{"status": "Password hash unchanged"}`))
+		return
+	}
+
+	nc.Publish(m.Reply, []byte(`{"status": "Password hash updated"}`))
+}
@@ -9,9 +9,25 @@ type Song struct {
 	Album          string             `bson:"album"`
 	Description    string             `bson:"description"`
 	UploadedBy     string             `bson:"uploadedBy"`
-	SongFileID     primitive.ObjectID `bson:"songFileID"`
-	AlbumCoverID   primitive.ObjectID `bson:"albumCoverID"`
+	SongFileID     string             `bson:"songFileID"`
+	AlbumCoverID   string             `bson:"albumCoverID"`
 	SongFileData   []byte             `bson:"-"`
 	AlbumCoverData []byte             `bson:"-"`
-	UploadedAt     primitive.DateTime `bson:"uploadedAt"`
+	UploadedAt     primitive.DateTime `bson:"uploadedAt,omitempty"`
+	SongFileSize   int64              `bson:"songFileSize"`
+	SongMimeType   string             `bson:"songMimeType"`
+	SongSHA256     string             `bson:"songSha256"`
+	CoverSize      int64              `bson:"albumCoverSize"`
+	CoverMimeType  string             `bson:"albumCoverMimeType"`
+	CoverSHA256    string             `bson:"albumCoverSha256"`
+	TrackNumber    int32              `bson:"trackNumber,omitempty"`
+	Year           int32              `bson:"year,omitempty"`
+	Genre          string             `bson:"genre,omitempty"`
+	DurationMs     int64              `bson:"durationMs,omitempty"`
+	SampleRate     int32              `bson:"sampleRate,omitempty"`
+	Channels       int32              `bson:"channels,omitempty"`
+	Bitrate        int32              `bson:"bitrate,omitempty"`
+	SearchTerms    []string           `bson:"searchTerms,omitempty"`
+	DeletedAt      primitive.DateTime `bson:"deletedAt,omitempty"`
+	Version        int64              `bson:"version"`
 }
@@ -2,8 +2,14 @@ package models
 
 import "go.mongodb.org/mongo-driver/bson/primitive"
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	ID       primitive.ObjectID `bson:"_id,omitempty"`
 	Username string             `bson:"username"`
 	Password string             `bson:"password"`
+	Roles    []string           `bson:"roles,omitempty"`
 }
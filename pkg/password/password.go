@@ -0,0 +1,122 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	argonTime    uint32 = 1
+	argonMemory  uint32 = 64 * 1024
+	argonThreads uint8  = 4
+	argonKeyLen  uint32 = 32
+	saltLen             = 16
+
+	// A stored hash may ask for at most this much work per verification;
+	// memory is in KiB.
+	maxArgonMemory uint32 = 256 * 1024
+	maxArgonTime   uint32 = 16
+)
+
+var (
+	ErrUnknownFormat = errors.New("password: unknown hash format")
+	ErrInvalidParams = errors.New("password: argon2id parameters out of range")
+)
+
+// Hash returns a PHC-style argon2id hash:
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("password: failed to generate salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		argonMemory,
+		argonTime,
+		argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks password against an encoded hash. It also accepts the legacy unsalted
+// SHA-256 hex digests; needsRehash reports that the stored hash should be replaced by Hash(password).
+func Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	if strings.HasPrefix(encoded, argon2idPrefix) {
+		return verifyArgon2id(password, encoded)
+	}
+
+	if isLegacySHA256(encoded) {
+		sum := sha256.Sum256([]byte(password))
+		ok := subtle.ConstantTimeCompare([]byte(hex.EncodeToString(sum[:])), []byte(strings.ToLower(encoded))) == 1
+		return ok, ok, nil
+	}
+
+	return false, false, ErrUnknownFormat
+}
+
+func verifyArgon2id(password, encoded string) (bool, bool, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, ErrUnknownFormat
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, false, ErrUnknownFormat
+	}
+
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, false, ErrUnknownFormat
+	}
+	if time < 1 || time > maxArgonTime || threads < 1 || memory < 8*uint32(threads) || memory > maxArgonMemory {
+		return false, false, ErrInvalidParams
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, ErrUnknownFormat
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return false, false, ErrUnknownFormat
+	}
+
+	candidate := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(candidate, key) != 1 {
+		return false, false, nil
+	}
+
+	needsRehash := version != argon2.Version ||
+		memory != argonMemory ||
+		time != argonTime ||
+		threads != argonThreads ||
+		uint32(len(key)) != argonKeyLen
+
+	return true, needsRehash, nil
+}
+
+func isLegacySHA256(encoded string) bool {
+	if len(encoded) != sha256.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(encoded)
+	return err == nil
+}
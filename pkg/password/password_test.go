@@ -0,0 +1,98 @@
+package password
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// encodeArgon2id builds a PHC string with the given parameters, so tests can
+// produce hashes Hash would not.
+func encodeArgon2id(password string, memory, time uint32, threads uint8, keyLen uint32) string {
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte(password), salt, time, memory, threads, keyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, memory, time, threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)
+}
+
+func TestVerify(t *testing.T) {
+	current, err := Hash("secret")
+	if err != nil {
+		t.Fatalf("Hash failed: %v", err)
+	}
+	legacy := sha256.Sum256([]byte("secret"))
+	legacyHex := hex.EncodeToString(legacy[:])
+
+	cases := []struct {
+		name            string
+		password        string
+		encoded         string
+		wantOK          bool
+		wantNeedsRehash bool
+		wantErr         error
+	}{
+		{name: "current hash", password: "secret", encoded: current, wantOK: true},
+		{name: "current hash, wrong password", password: "wrong", encoded: current},
+		{name: "legacy sha256", password: "secret", encoded: legacyHex, wantOK: true, wantNeedsRehash: true},
+		{name: "legacy sha256 upper case", password: "secret", encoded: strings.ToUpper(legacyHex), wantOK: true, wantNeedsRehash: true},
+		{name: "legacy sha256, wrong password", password: "wrong", encoded: legacyHex},
+		{name: "weaker parameters", password: "secret", encoded: encodeArgon2id("secret", 32*1024, 1, 2, 32), wantOK: true, wantNeedsRehash: true},
+		{name: "shorter key", password: "secret", encoded: encodeArgon2id("secret", argonMemory, argonTime, argonThreads, 16), wantOK: true, wantNeedsRehash: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok, needsRehash, err := Verify(c.password, c.encoded)
+			if err != c.wantErr {
+				t.Fatalf("Verify error = %v, want %v", err, c.wantErr)
+			}
+			if ok != c.wantOK || needsRehash != c.wantNeedsRehash {
+				t.Errorf("Verify = %v, %v; want %v, %v", ok, needsRehash, c.wantOK, c.wantNeedsRehash)
+			}
+		})
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	const salt, key = "MDEyMzQ1Njc4OWFiY2RlZg", "a2V5a2V5a2V5a2V5"
+
+	cases := []struct {
+		name    string
+		encoded string
+		wantErr error
+	}{
+		{"zero time", "$argon2id$v=19$m=65536,t=0,p=4$" + salt + "$" + key, ErrInvalidParams},
+		{"excessive time", "$argon2id$v=19$m=65536,t=1000,p=4$" + salt + "$" + key, ErrInvalidParams},
+		{"zero threads", "$argon2id$v=19$m=65536,t=1,p=0$" + salt + "$" + key, ErrInvalidParams},
+		{"memory below 8 per thread", "$argon2id$v=19$m=16,t=1,p=4$" + salt + "$" + key, ErrInvalidParams},
+		{"excessive memory", "$argon2id$v=19$m=4294967295,t=1,p=4$" + salt + "$" + key, ErrInvalidParams},
+		{"missing section", "$argon2id$v=19$m=65536,t=1,p=4$" + salt, ErrUnknownFormat},
+		{"bad version", "$argon2id$version$m=65536,t=1,p=4$" + salt + "$" + key, ErrUnknownFormat},
+		{"bad parameters", "$argon2id$v=19$memory$" + salt + "$" + key, ErrUnknownFormat},
+		{"threads overflow", "$argon2id$v=19$m=65536,t=1,p=256$" + salt + "$" + key, ErrUnknownFormat},
+		{"bad salt", "$argon2id$v=19$m=65536,t=1,p=4$!!!$" + key, ErrUnknownFormat},
+		{"empty key", "$argon2id$v=19$m=65536,t=1,p=4$" + salt + "$", ErrUnknownFormat},
+		{"other algorithm", "$2a$10$abcdefghijklmnopqrstuv", ErrUnknownFormat},
+		{"empty", "", ErrUnknownFormat},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ok, _, err := Verify("secret", c.encoded)
+			if err != c.wantErr {
+				t.Errorf("Verify error = %v, want %v", err, c.wantErr)
+			}
+			if ok {
+				t.Error("Verify accepted the password")
+			}
+		})
+	}
+}
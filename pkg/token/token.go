@@ -0,0 +1,191 @@
+package token
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	SecretEnv       = "JWT_SECRET_KEY"
+	Issuer          = "music-player-go"
+	AccessTokenTTL  = 15 * time.Minute
+	RefreshTokenTTL = 30 * 24 * time.Hour
+
+	TypeAccess  = "access"
+	TypeRefresh = "refresh"
+)
+
+var (
+	ErrMissingSecret = errors.New("token: JWT_SECRET_KEY is not set")
+	ErrMissingToken  = errors.New("token: missing bearer token")
+	ErrInvalidToken  = errors.New("token: invalid token")
+)
+
+func init() {
+	// Issue times carry milliseconds so revocation by time can tell a token
+	// issued right after logging out everywhere from one issued before.
+	jwt.TimePrecision = time.Millisecond
+}
+
+// Claims is the payload carried by every token issued by the auth service.
+// Family groups a chain of rotated refresh tokens so reuse of an old one can revoke all of them.
+type Claims struct {
+	UserID   string   `json:"userId"`
+	Username string   `json:"userName"`
+	Roles    []string `json:"roles,omitempty"`
+	Type     string   `json:"typ"`
+	Family   string   `json:"fam,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Pair is an access token together with the refresh token that can replace it.
+type Pair struct {
+	AccessToken      string
+	AccessExpiresAt  time.Time
+	RefreshToken     string
+	RefreshID        string
+	RefreshExpiresAt time.Time
+	Family           string
+}
+
+// SecretFromEnv reads the signing key shared by the auth service and every
+// verifier. Services call it at startup and exit without it; docker-compose
+// passes it through from .env.
+func SecretFromEnv() ([]byte, error) {
+	secret := os.Getenv(SecretEnv)
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	return []byte(secret), nil
+}
+
+// IssuePair signs a new access/refresh token pair. An empty family starts a new refresh chain.
+func IssuePair(secret []byte, userID, username string, roles []string, family string) (*Pair, error) {
+	if family == "" {
+		family = NewID()
+	}
+
+	now := time.Now()
+	access := newClaims(userID, username, roles, TypeAccess, "", now, AccessTokenTTL)
+	refresh := newClaims(userID, username, roles, TypeRefresh, family, now, RefreshTokenTTL)
+
+	accessToken, err := sign(secret, access)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := sign(secret, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pair{
+		AccessToken:      accessToken,
+		AccessExpiresAt:  access.ExpiresAt.Time,
+		RefreshToken:     refreshToken,
+		RefreshID:        refresh.ID,
+		RefreshExpiresAt: refresh.ExpiresAt.Time,
+		Family:           family,
+	}, nil
+}
+
+// Verify checks the signature, issuer, type and expiry of an access token without any network round trip.
+func Verify(secret []byte, tokenString string) (*Claims, error) {
+	return parse(secret, tokenString, TypeAccess)
+}
+
+// VerifyRefresh is Verify for refresh tokens. Callers must still check revocation state.
+func VerifyRefresh(secret []byte, tokenString string) (*Claims, error) {
+	return parse(secret, tokenString, TypeRefresh)
+}
+
+// FromAuthorizationHeader extracts the token from an "Authorization: Bearer <token>" value.
+func FromAuthorizationHeader(header string) (string, error) {
+	scheme, tokenString, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", ErrMissingToken
+	}
+
+	tokenString = strings.TrimSpace(tokenString)
+	if tokenString == "" {
+		return "", ErrMissingToken
+	}
+
+	return tokenString, nil
+}
+
+func NewID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("token: failed to read random bytes: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func newClaims(userID, username string, roles []string, tokenType, family string, now time.Time, ttl time.Duration) *Claims {
+	return &Claims{
+		UserID:   userID,
+		Username: username,
+		Roles:    roles,
+		Type:     tokenType,
+		Family:   family,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        NewID(),
+			Issuer:    Issuer,
+			Subject:   userID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+}
+
+func sign(secret []byte, claims *Claims) (string, error) {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", fmt.Errorf("token: failed to sign: %w", err)
+	}
+	return signed, nil
+}
+
+func parse(secret []byte, tokenString, tokenType string) (*Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (interface{}, error) {
+		return secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if claims.Type != tokenType {
+		return nil, fmt.Errorf("%w: expected %s token", ErrInvalidToken, tokenType)
+	}
+	if claims.UserID == "" || claims.ID == "" {
+		return nil, fmt.Errorf("%w: missing user or token ID", ErrInvalidToken)
+	}
+	if tokenType == TypeRefresh && claims.Family == "" {
+		return nil, fmt.Errorf("%w: missing token family", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,153 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var testSecret = []byte("test-secret")
+
+func signWith(t *testing.T, method jwt.SigningMethod, key interface{}, claims *Claims) string {
+	t.Helper()
+	signed, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	pair, err := IssuePair(testSecret, "user-1", "alice", []string{"user"}, "")
+	if err != nil {
+		t.Fatalf("IssuePair failed: %v", err)
+	}
+
+	now := time.Now()
+	access := func() *Claims { return newClaims("user-1", "alice", nil, TypeAccess, "", now, AccessTokenTTL) }
+	wrongIssuer := access()
+	wrongIssuer.Issuer = "someone-else"
+	noUser := access()
+	noUser.UserID = ""
+
+	cases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"access token", pair.AccessToken, false},
+		{"refresh token", pair.RefreshToken, true},
+		{"wrong issuer", signWith(t, jwt.SigningMethodHS256, testSecret, wrongIssuer), true},
+		{"wrong secret", signWith(t, jwt.SigningMethodHS256, []byte("other-secret"), access()), true},
+		{"alg none", signWith(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, access()), true},
+		{"alg RS256", signWith(t, jwt.SigningMethodRS256, rsaKey, access()), true},
+		{"alg HS512", signWith(t, jwt.SigningMethodHS512, testSecret, access()), true},
+		{"expired", signWith(t, jwt.SigningMethodHS256, testSecret, newClaims("user-1", "alice", nil, TypeAccess, "", now.Add(-time.Hour), time.Minute)), true},
+		{"missing user", signWith(t, jwt.SigningMethodHS256, testSecret, noUser), true},
+		{"garbage", "not.a.token", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			claims, err := Verify(testSecret, c.token)
+			if c.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("Verify error = %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if claims.UserID != "user-1" || claims.Username != "alice" || !claims.HasRole("user") {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyRefresh(t *testing.T) {
+	pair, err := IssuePair(testSecret, "user-1", "alice", nil, "family-1")
+	if err != nil {
+		t.Fatalf("IssuePair failed: %v", err)
+	}
+
+	noFamily := newClaims("user-1", "alice", nil, TypeRefresh, "", time.Now(), RefreshTokenTTL)
+
+	cases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"refresh token", pair.RefreshToken, false},
+		{"access token", pair.AccessToken, true},
+		{"missing family", signWith(t, jwt.SigningMethodHS256, testSecret, noFamily), true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			claims, err := VerifyRefresh(testSecret, c.token)
+			if c.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Fatalf("VerifyRefresh error = %v, want %v", err, ErrInvalidToken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyRefresh failed: %v", err)
+			}
+			if claims.Family != "family-1" || claims.ID != pair.RefreshID {
+				t.Errorf("claims = %+v", claims)
+			}
+		})
+	}
+}
+
+func TestFromAuthorizationHeader(t *testing.T) {
+	cases := []struct {
+		header  string
+		want    string
+		wantErr bool
+	}{
+		{"Bearer abc", "abc", false},
+		{"bearer  abc ", "abc", false},
+		{"Basic abc", "", true},
+		{"Bearer", "", true},
+		{"Bearer   ", "", true},
+		{"", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := FromAuthorizationHeader(c.header)
+		if (err != nil) != c.wantErr || got != c.want {
+			t.Errorf("FromAuthorizationHeader(%q) = %q, %v; want %q, error %v", c.header, got, err, c.want, c.wantErr)
+		}
+	}
+}
+
+func TestIssuedAtKeepsMilliseconds(t *testing.T) {
+	before := time.Now()
+	pair, err := IssuePair(testSecret, "user-1", "alice", nil, "")
+	if err != nil {
+		t.Fatalf("IssuePair failed: %v", err)
+	}
+	after := time.Now()
+
+	claims, err := VerifyRefresh(testSecret, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("VerifyRefresh failed: %v", err)
+	}
+	// Kept to the millisecond, give or take float rounding, not to the second.
+	if claims.IssuedAt.Before(before.Add(-2*time.Millisecond)) || claims.IssuedAt.After(after.Add(time.Millisecond)) {
+		t.Errorf("IssuedAt = %v, want between %v and %v", claims.IssuedAt.Time, before, after)
+	}
+}